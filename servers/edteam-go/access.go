@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	AccessFree                   = "free"
+	AccessOwned                  = "owned"
+	AccessIncludedInSubscription = "included_in_subscription"
+	AccessRequiresPurchase       = "requires_purchase"
+)
+
+type CourseAccess struct {
+	CourseID           int        `json:"course_id"`
+	Name               string     `json:"name"`
+	Slug               string     `json:"slug"`
+	Access             string     `json:"access"`
+	SubscriptionEndsAt *time.Time `json:"subscription_ends_at,omitempty"`
+	Price              *int       `json:"price,omitempty"`
+	Currency           string     `json:"currency,omitempty"`
+	Message            string     `json:"message"`
+}
+
+// activeSubscription returns the active subscription whose period includes
+// now. A cancelled or expired subscription doesn't count even before its end
+// date.
+func activeSubscription(subscriptions SubscriptionResponse, now time.Time) (Subscription, bool) {
+	for _, subscription := range subscriptions.Data {
+		if !strings.EqualFold(subscription.State, SubscriptionActive) {
+			continue
+		}
+		if !now.Before(subscription.BeginsAt.Time) && now.Before(subscription.EndsAt.Time) {
+			return subscription, true
+		}
+	}
+
+	return Subscription{}, false
+}
+
+// ownsCourse tells whether the course was bought individually.
+func ownsCourse(owned OwnedCoursesResponse, courseID int) bool {
+	for _, course := range owned.Data {
+		if course.ID == courseID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// courseAccess tells whether the user can watch the course at index i. A
+// course is free when all its prices are zero, a course bought individually
+// is kept forever and an active subscription includes every course of the
+// catalog.
+func courseAccess(courses CourseResponse, i int, owned OwnedCoursesResponse, subscriptions SubscriptionResponse, codes map[int]string, now time.Time, locale Locale) CourseAccess {
+	item := courses.Data[i]
+	access := CourseAccess{
+		CourseID: item.Course.ID,
+		Name:     item.Course.Name,
+		Slug:     item.Course.Slug,
+	}
+
+	free := true
+	for _, price := range item.CoursePrices {
+		if price.Price > 0 {
+			free = false
+		}
+	}
+	if len(item.CoursePrices) > 0 {
+		price := item.CoursePrices[0].Price
+		access.Price = &price
+		access.Currency = codes[item.CoursePrices[0].CurrencyId]
+	}
+
+	subscription, active := activeSubscription(subscriptions, now)
+	switch {
+	case free && len(item.CoursePrices) > 0:
+		access.Access = AccessFree
+		access.Message = locale.T("access_free")
+	case ownsCourse(owned, item.Course.ID):
+		access.Access = AccessOwned
+		access.Message = locale.T("access_owned")
+	case active:
+		access.Access = AccessIncludedInSubscription
+		access.SubscriptionEndsAt = &subscription.EndsAt.Time
+		access.Message = locale.T("access_included", relativeTime(now, subscription.EndsAt.Time, locale))
+	default:
+		access.Access = AccessRequiresPurchase
+		access.Message = locale.T("access_requires_purchase")
+	}
+
+	return access
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+)
+
+func TestCourseAccess(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	courses := CourseResponse{Data: []Course{
+		{Course: edteam.CourseDetails{ID: 1, Name: "Go"}, CoursePrices: []CoursePrice{{Price: 0}}},
+		{Course: edteam.CourseDetails{ID: 2, Name: "Rust"}, CoursePrices: []CoursePrice{{Price: 30}}},
+	}}
+	subscription := func(state string) SubscriptionResponse {
+		return SubscriptionResponse{Data: []Subscription{{
+			State:    state,
+			BeginsAt: edteam.Time{Time: now.AddDate(0, -1, 0)},
+			EndsAt:   edteam.Time{Time: now.AddDate(0, 1, 0)},
+		}}}
+	}
+	owned := OwnedCoursesResponse{Data: []OwnedCourse{{ID: 2}}}
+
+	tests := []struct {
+		name          string
+		i             int
+		owned         OwnedCoursesResponse
+		subscriptions SubscriptionResponse
+		want          string
+	}{
+		{name: "free course", i: 0, want: AccessFree},
+		{name: "course bought individually", i: 1, owned: owned, want: AccessOwned},
+		{name: "bought and subscribed", i: 1, owned: owned, subscriptions: subscription("active"), want: AccessOwned},
+		{name: "active subscription", i: 1, subscriptions: subscription("active"), want: AccessIncludedInSubscription},
+		{name: "cancelled subscription", i: 1, subscriptions: subscription("cancelled"), want: AccessRequiresPurchase},
+		{name: "finished subscription", i: 1, subscriptions: subscription("finished"), want: AccessRequiresPurchase},
+		{name: "nothing", i: 1, want: AccessRequiresPurchase},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := courseAccess(courses, tt.i, tt.owned, tt.subscriptions, nil, now, LocaleEN)
+			if got.Access != tt.want {
+				t.Errorf("courseAccess() = %q, want %q", got.Access, tt.want)
+			}
+		})
+	}
+}
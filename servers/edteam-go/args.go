@@ -0,0 +1,309 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxSafeInt is the biggest integer a JSON number holds without losing
+// precision, it bounds every integer argument.
+const MaxSafeInt = 1<<53 - 1
+
+// ArgumentError tells which argument of a tool call is invalid and why.
+type ArgumentError struct {
+	Argument string
+	Reason   string
+}
+
+func (e *ArgumentError) Error() string {
+	return fmt.Sprintf("invalid argument %q: %s", e.Argument, e.Reason)
+}
+
+// Args binds the arguments of a tool call into typed values, validating them
+// against the same constraints declared in the tool schema. The first invalid
+// argument is kept and returned by Err, so handlers can read every argument
+// and check for errors once.
+//
+// Coercion rules:
+//   - missing and null arguments take the default value.
+//   - numbers must be integers, strings holding an integer ("2") are accepted.
+//   - strings are trimmed, an empty string takes the default value.
+//   - booleans accept the strings "true" and "false".
+//   - values out of range or outside the enum are errors, they are never
+//     replaced silently.
+type Args struct {
+	values map[string]any
+	err    error
+}
+
+func NewArgs(values map[string]any) *Args {
+	return &Args{values: values}
+}
+
+// Err returns the first invalid argument found.
+func (a *Args) Err() error {
+	return a.err
+}
+
+func (a *Args) fail(name, format string, args ...any) {
+	if a.err == nil {
+		a.err = &ArgumentError{Argument: name, Reason: fmt.Sprintf(format, args...)}
+	}
+}
+
+// Int returns the integer argument name, which must be between min and max.
+func (a *Args) Int(name string, def, min, max int) int {
+	value, ok := a.values[name]
+	if !ok || value == nil {
+		return def
+	}
+
+	return a.int(name, value, min, max, def)
+}
+
+// RequireAny fails unless at least one of the arguments names is present
+// and not empty, for tools where every argument is optional on its own.
+func (a *Args) RequireAny(names ...string) {
+	for _, name := range names {
+		value, ok := a.values[name]
+		if s, isString := value.(string); ok && value != nil && (!isString || strings.TrimSpace(s) != "") {
+			return
+		}
+	}
+	a.fail(strings.Join(names, ", "), "at least one is required")
+}
+
+// RequiredString returns the string argument name, which must be present and
+// not empty.
+func (a *Args) RequiredString(name string) string {
+	s := a.String(name, "")
+	if s == "" {
+		a.fail(name, "is required")
+	}
+
+	return s
+}
+
+// RequiredInt returns the integer argument name, which must be present and
+// between min and max.
+func (a *Args) RequiredInt(name string, min, max int) int {
+	value, ok := a.values[name]
+	// An empty string would take the default, a required argument has none.
+	if s, isString := value.(string); !ok || value == nil || isString && strings.TrimSpace(s) == "" {
+		a.fail(name, "is required")
+		return 0
+	}
+
+	return a.int(name, value, min, max, 0)
+}
+
+func (a *Args) int(name string, value any, min, max, def int) int {
+	var n float64
+	switch v := value.(type) {
+	case float64:
+		n = v
+	case int:
+		n = float64(v)
+	case string:
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return def
+		}
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			a.fail(name, "must be an integer, got %q", v)
+			return def
+		}
+		n = float64(parsed)
+	default:
+		a.fail(name, "must be an integer, got %T", value)
+		return def
+	}
+
+	if max > MaxSafeInt {
+		max = MaxSafeInt
+	}
+	if n != math.Trunc(n) || math.IsInf(n, 0) {
+		a.fail(name, "must be an integer, got %v", n)
+		return def
+	}
+	if n < float64(min) || n > float64(max) {
+		if max == MaxSafeInt {
+			a.fail(name, "must be greater than or equal to %d, got %v", min, n)
+		} else {
+			a.fail(name, "must be between %d and %d, got %v", min, max, n)
+		}
+		return def
+	}
+
+	return int(n)
+}
+
+// String returns the string argument name. When enum is not empty the value
+// must be one of its elements.
+func (a *Args) String(name, def string, enum ...string) string {
+	value, ok := a.values[name]
+	if !ok || value == nil {
+		return def
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		a.fail(name, "must be a string, got %T", value)
+		return def
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return def
+	}
+	if len(enum) > 0 && !contains(enum, s) {
+		a.fail(name, "must be one of %s, got %q", strings.Join(enum, ", "), s)
+		return def
+	}
+
+	return s
+}
+
+// Bool returns the boolean argument name. The strings "true" and "false" are
+// accepted too.
+func (a *Args) Bool(name string, def bool) bool {
+	value, ok := a.values[name]
+	if !ok || value == nil {
+		return def
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "":
+			return def
+		}
+	}
+	a.fail(name, "must be a boolean, got %v", value)
+
+	return def
+}
+
+// Match returns the string argument name, which must match pattern.
+func (a *Args) Match(name string, pattern *regexp.Regexp, description string) string {
+	s := a.String(name, "")
+	if s != "" && !pattern.MatchString(s) {
+		a.fail(name, "must be %s, got %q", description, s)
+		return ""
+	}
+
+	return s
+}
+
+// Strings returns the array of strings argument name. When allowed is not
+// empty every element must be one of its elements.
+func (a *Args) Strings(name string, allowed []string) []string {
+	value, ok := a.values[name]
+	if !ok || value == nil {
+		return nil
+	}
+
+	raw, ok := value.([]any)
+	if !ok {
+		a.fail(name, "must be an array of strings, got %T", value)
+		return nil
+	}
+
+	values := make([]string, 0, len(raw))
+	for _, item := range raw {
+		s, ok := item.(string)
+		if !ok {
+			a.fail(name, "must be an array of strings, got an element of type %T", item)
+			return nil
+		}
+		if len(allowed) > 0 && !contains(allowed, s) {
+			a.fail(name, "unknown value %q, use any of %s", s, strings.Join(allowed, ", "))
+			return nil
+		}
+		values = append(values, s)
+	}
+
+	return values
+}
+
+// MatchStrings returns the array of strings argument name, every element
+// must match pattern.
+func (a *Args) MatchStrings(name string, pattern *regexp.Regexp, description string) []string {
+	values := a.Strings(name, nil)
+	for _, s := range values {
+		if !pattern.MatchString(s) {
+			a.fail(name, "every element must be %s, got %q", description, s)
+			return nil
+		}
+	}
+
+	return values
+}
+
+// Date returns the date argument name in the YYYY-MM-DD format, or nil when
+// it is missing.
+func (a *Args) Date(name string) *time.Time {
+	s := a.String(name, "")
+	if s == "" {
+		return nil
+	}
+
+	date, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		a.fail(name, "must be a date like 2025-12-31, got %q", s)
+		return nil
+	}
+
+	return &date
+}
+
+// Ints returns the array of integers argument name, every element must be
+// between min and max.
+func (a *Args) Ints(name string, min, max int) []int {
+	value, ok := a.values[name]
+	if !ok || value == nil {
+		return nil
+	}
+
+	raw, ok := value.([]any)
+	if !ok {
+		a.fail(name, "must be an array of integers, got %T", value)
+		return nil
+	}
+
+	values := make([]int, 0, len(raw))
+	for _, item := range raw {
+		values = append(values, a.int(name, item, min, max, 0))
+	}
+	if a.err != nil {
+		return nil
+	}
+
+	return values
+}
+
+// Locale returns the locale argument or fallback when it is missing.
+func (a *Args) Locale(fallback Locale) Locale {
+	value := a.String("locale", "")
+	if value == "" {
+		return fallback
+	}
+
+	locale, ok := ParseLocale(value)
+	if !ok {
+		a.fail("locale", "must be one of %s, %s, got %q", LocaleES, LocaleEN, value)
+		return fallback
+	}
+
+	return locale
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// The tables below are the coercion rules of Args, see its doc comment.
+
+func TestArgsInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]any
+		want    int
+		wantErr bool
+	}{
+		{name: "missing key takes the default", values: map[string]any{}, want: 1},
+		{name: "null takes the default", values: map[string]any{"page": nil}, want: 1},
+		{name: "JSON number", values: map[string]any{"page": 3.0}, want: 3},
+		{name: "Go int", values: map[string]any{"page": 3}, want: 3},
+		{name: "string holding an integer", values: map[string]any{"page": "3"}, want: 3},
+		{name: "string with spaces", values: map[string]any{"page": " 3 "}, want: 3},
+		{name: "empty string takes the default", values: map[string]any{"page": "  "}, want: 1},
+		{name: "string holding a decimal", values: map[string]any{"page": "3.5"}, want: 1, wantErr: true},
+		{name: "string in exponent notation", values: map[string]any{"page": "1e3"}, want: 1, wantErr: true},
+		{name: "string that isn't a number", values: map[string]any{"page": "two"}, want: 1, wantErr: true},
+		{name: "decimal number", values: map[string]any{"page": 2.5}, want: 1, wantErr: true},
+		{name: "whole decimal number", values: map[string]any{"page": 2.0}, want: 2},
+		{name: "zero page", values: map[string]any{"page": 0.0}, want: 1, wantErr: true},
+		{name: "negative page", values: map[string]any{"page": -1.0}, want: 1, wantErr: true},
+		{name: "negative page as a string", values: map[string]any{"page": "-1"}, want: 1, wantErr: true},
+		{name: "largest safe integer", values: map[string]any{"page": float64(MaxSafeInt)}, want: MaxSafeInt},
+		{name: "past the largest safe integer", values: map[string]any{"page": float64(MaxSafeInt + 2)}, want: 1, wantErr: true},
+		{name: "huge number", values: map[string]any{"page": 1e300}, want: 1, wantErr: true},
+		{name: "boolean", values: map[string]any{"page": true}, want: 1, wantErr: true},
+		{name: "array", values: map[string]any{"page": []any{2.0}}, want: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := NewArgs(tt.values)
+			got := args.Int("page", 1, 1, MaxSafeInt)
+			if got != tt.want {
+				t.Errorf("Int() = %d, want %d", got, tt.want)
+			}
+			checkArgumentError(t, args.Err(), "page", tt.wantErr)
+		})
+	}
+}
+
+// TestArgsIntLimit checks a bounded integer like the limit of a page:
+// values out of range fail instead of being clamped.
+func TestArgsIntLimit(t *testing.T) {
+	tests := []struct {
+		value   any
+		want    int
+		wantErr bool
+	}{
+		{value: 10.0, want: 10},
+		{value: 100.0, want: 100},
+		{value: 101.0, want: 10, wantErr: true},
+		{value: "1000000", want: 10, wantErr: true},
+		{value: 1e18, want: 10, wantErr: true},
+		{value: "9999999999999999999999", want: 10, wantErr: true},
+	}
+	for _, tt := range tests {
+		args := NewArgs(map[string]any{"limit": tt.value})
+		if got := args.Int("limit", 10, 1, 100); got != tt.want {
+			t.Errorf("Int(%v) = %d, want %d", tt.value, got, tt.want)
+		}
+		checkArgumentError(t, args.Err(), "limit", tt.wantErr)
+	}
+}
+
+func TestArgsRequired(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]any
+	}{
+		{name: "missing key", values: map[string]any{}},
+		{name: "null", values: map[string]any{"course_id": nil, "slug": nil}},
+		{name: "empty string", values: map[string]any{"course_id": "", "slug": " "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := NewArgs(tt.values)
+			args.RequiredInt("course_id", 1, MaxSafeInt)
+			checkArgumentError(t, args.Err(), "course_id", true)
+
+			args = NewArgs(tt.values)
+			args.RequiredString("slug")
+			checkArgumentError(t, args.Err(), "slug", true)
+		})
+	}
+}
+
+func TestArgsString(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    string
+		wantErr bool
+	}{
+		{name: "value of the enum", value: SortName, want: SortName},
+		{name: "trimmed", value: " " + SortName + " ", want: SortName},
+		{name: "empty string takes the default", value: "", want: ""},
+		{name: "null takes the default", value: nil, want: ""},
+		{name: "outside the enum", value: "popular", wantErr: true},
+		{name: "other case", value: "NAME", wantErr: true},
+		{name: "number", value: 1.0, wantErr: true},
+		{name: "array", value: []any{SortName}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := NewArgs(map[string]any{"sort": tt.value})
+			if got := args.String("sort", "", sortOptions...); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			checkArgumentError(t, args.Err(), "sort", tt.wantErr)
+		})
+	}
+}
+
+func TestArgsBool(t *testing.T) {
+	tests := []struct {
+		value   any
+		want    bool
+		wantErr bool
+	}{
+		{value: true, want: true},
+		{value: false, want: false},
+		{value: "true", want: true},
+		{value: " FALSE ", want: false},
+		{value: "", want: true},
+		{value: nil, want: true},
+		{value: "yes", want: true, wantErr: true},
+		{value: 1.0, want: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		args := NewArgs(map[string]any{"summary": tt.value})
+		if got := args.Bool("summary", true); got != tt.want {
+			t.Errorf("Bool(%#v) = %v, want %v", tt.value, got, tt.want)
+		}
+		checkArgumentError(t, args.Err(), "summary", tt.wantErr)
+	}
+}
+
+func TestArgsStrings(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    []string
+		wantErr bool
+	}{
+		{name: "known fields", value: []any{"id", "name"}, want: []string{"id", "name"}},
+		{name: "empty array", value: []any{}, want: []string{}},
+		{name: "null", value: nil},
+		{name: "unknown field", value: []any{"id", "password"}, wantErr: true},
+		{name: "a string instead of an array", value: "id,name", wantErr: true},
+		{name: "an element that isn't a string", value: []any{"id", 2.0}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := NewArgs(map[string]any{"fields": tt.value})
+			if got := args.Strings("fields", courseFields); !slices.Equal(got, tt.want) {
+				t.Errorf("Strings() = %q, want %q", got, tt.want)
+			}
+			checkArgumentError(t, args.Err(), "fields", tt.wantErr)
+		})
+	}
+}
+
+func TestArgsDate(t *testing.T) {
+	args := NewArgs(map[string]any{"since": "2025-12-31"})
+	if got := args.Date("since"); got == nil || !got.Equal(time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Date() = %v, want 2025-12-31", got)
+	}
+
+	for _, value := range []any{"31/12/2025", "2025-02-30", "2025-12-31T00:00:00Z", 20251231.0} {
+		args := NewArgs(map[string]any{"since": value})
+		if got := args.Date("since"); got != nil {
+			t.Errorf("Date(%#v) = %v, want nil", value, got)
+		}
+		checkArgumentError(t, args.Err(), "since", true)
+	}
+}
+
+// TestArgsFirstError keeps the first invalid argument, the handlers read
+// every argument before checking Err.
+func TestArgsFirstError(t *testing.T) {
+	args := NewArgs(map[string]any{"page": -1.0, "limit": "many"})
+	args.Int("page", 1, 1, MaxSafeInt)
+	args.Int("limit", 10, 1, 100)
+	checkArgumentError(t, args.Err(), "page", true)
+}
+
+func checkArgumentError(t *testing.T, err error, argument string, want bool) {
+	t.Helper()
+
+	var argErr *ArgumentError
+	switch {
+	case !want && err != nil:
+		t.Errorf("Err() = %v, want nil", err)
+	case want && !errors.As(err, &argErr):
+		t.Errorf("Err() = %v, want an *ArgumentError", err)
+	case want && argErr.Argument != argument:
+		t.Errorf("Err() is about %q, want %q", argErr.Argument, argument)
+	}
+}
+
+func BenchmarkArgs(b *testing.B) {
+	values := map[string]any{"page": 2.0, "limit": 10.0, "currency": "pen", "fields": []any{"id", "name"}, "locale": "en"}
+	b.ReportAllocs()
+	for b.Loop() {
+		args := NewArgs(values)
+		args.Locale(LocaleES)
+		args.Int("page", 1, 1, MaxSafeInt)
+		args.Int("limit", 10, 1, 10)
+		args.Match("currency", currencyPattern, "the currency must be an ISO 4217 code")
+		args.String("sort", "", sortOptions...)
+		args.Strings("fields", courseFields)
+		args.String("format", FormatJSON, formats...)
+		if err := args.Err(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// FuzzArgs binds the arguments of Courses-List from any JSON object, like a
+// model could send them, and checks that only valid values get through.
+func FuzzArgs(f *testing.F) {
+	for _, seed := range []string{
+		`{"page":2,"limit":10,"currency":"pen","fields":["id","name"]}`,
+		`{"page":"3","limit":"1e3"}`,
+		`{"page":-1,"limit":null}`,
+		`{"page":1.5,"limit":9007199254740993}`,
+		`{"page":"2) OR key(x","currency":"PEN\u0000"}`,
+		`{"fields":"id","sort":["name"],"locale":7}`,
+		`{"cursor":"eyJwIjo0NjExNjg2MDE4NDI3Mzg3OTA1LCJsIjoyfQ"}`,
+		`{"cursor":"eyJwIjoyLCJsIjoxMH0","limit":5}`,
+		`{}`,
+	} {
+		f.Add(seed)
+	}
+	catalog := CourseResponse{Data: make([]Course, 25)}
+	for i := range catalog.Data {
+		catalog.Data[i].Course.ID = i + 1
+	}
+	f.Fuzz(func(t *testing.T, raw string) {
+		var values map[string]any
+		if err := json.Unmarshal([]byte(raw), &values); err != nil {
+			return
+		}
+
+		args := NewArgs(values)
+		page := args.Int("page", 1, 1, MaxSafeInt)
+		limit := args.Int("limit", 10, 1, 100)
+		currency := args.Match("currency", currencyPattern, "an ISO 4217 code")
+		sort := args.String("sort", "", sortOptions...)
+		fields := args.Strings("fields", courseFields)
+		page, limit = args.Cursor(page, limit, 100)
+		if args.Err() != nil {
+			return
+		}
+		if page < 1 || page > MaxSafeInt || limit < 1 || limit > 100 {
+			t.Errorf("Args(%s) bound page %d and limit %d", raw, page, limit)
+		}
+		if currency != "" && !currencyPattern.MatchString(currency) {
+			t.Errorf("Args(%s) bound the currency %q", raw, currency)
+		}
+		if sort != "" && !contains(sortOptions, sort) {
+			t.Errorf("Args(%s) bound the sort %q", raw, sort)
+		}
+		for _, field := range fields {
+			if !contains(courseFields, field) {
+				t.Errorf("Args(%s) bound the field %q", raw, field)
+			}
+		}
+
+		got := pageOf(catalog.Data, page, limit)
+		want := 0
+		if page <= len(catalog.Data) {
+			want = max(0, min(limit, len(catalog.Data)-(page-1)*limit))
+		}
+		if len(got) != want {
+			t.Errorf("Args(%s) page %d of %d courses has %d courses, want %d", raw, page, limit, len(got), want)
+		}
+		if len(got) > 0 && got[0].Course.ID != (page-1)*limit+1 {
+			t.Errorf("Args(%s) page %d of %d courses starts at course %d", raw, page, limit, got[0].Course.ID)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package main
+
+import "regexp"
+
+var (
+	countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	emailPattern   = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+// mergeBillingAddress overwrites the fields of address with the ones that
+// aren't empty in changes.
+func mergeBillingAddress(address, changes BillingAddress) BillingAddress {
+	set := func(field *string, value string) {
+		if value != "" {
+			*field = value
+		}
+	}
+	set(&address.Name, changes.Name)
+	set(&address.TaxID, changes.TaxID)
+	set(&address.Address, changes.Address)
+	set(&address.City, changes.City)
+	set(&address.State, changes.State)
+	set(&address.PostalCode, changes.PostalCode)
+	set(&address.Country, changes.Country)
+
+	return address
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+	"golang.org/x/sync/singleflight"
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// maxCatalogPages protects the catalog walk from an upstream that never
+// returns a short page.
+const maxCatalogPages = 500
+
+// catalogFetchTimeout bounds a catalog walk. The walk is shared by every
+// caller waiting for it, so it doesn't end when the caller that started it
+// gives up.
+const catalogFetchTimeout = 5 * time.Minute
+
+// Catalog keeps the full list of courses in memory for ttl, so the tools
+// that need to look up a course don't walk the catalog on every call. The
+// callers that find it expired share a single walk, which asks EDteam for
+// locale whatever the locale of the caller.
+type Catalog struct {
+	api      edteam.CoursesAPI
+	ttl      time.Duration
+	pageSize int
+	locale   Locale
+	fetches  singleflight.Group
+
+	mu        sync.Mutex
+	courses   CourseResponse
+	fetchedAt time.Time
+	observers []func(context.Context, CourseResponse)
+	// path is the file the catalog is persisted in, empty keeps it in memory.
+	path string
+}
+
+type persistedCatalog struct {
+	FetchedAt time.Time      `json:"fetched_at"`
+	Courses   CourseResponse `json:"courses"`
+}
+
+func NewCatalog(api edteam.CoursesAPI, ttl time.Duration, pageSize int, locale Locale) *Catalog {
+	return &Catalog{api: api, ttl: ttl, pageSize: pageSize, locale: locale}
+}
+
+// Persist writes every fetched catalog to path and loads the one written by
+// a previous run, which is used until it is older than the ttl.
+func (c *Catalog) Persist(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.path = path
+	var saved persistedCatalog
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, &saved) != nil {
+		return
+	}
+	c.courses = saved.Courses
+	c.fetchedAt = saved.FetchedAt
+}
+
+// OnFetch registers fn to be called with every fresh copy of the catalog,
+// before it is cached. The observers run outside the lock, a slow one only
+// delays the callers waiting for the walk.
+func (c *Catalog) OnFetch(fn func(context.Context, CourseResponse)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.observers = append(c.observers, fn)
+}
+
+// Courses returns a copy of every course of the catalog, fetching it again
+// when the cached copy is older than the ttl. Callers are free to modify it.
+func (c *Catalog) Courses(ctx context.Context) (CourseResponse, error) {
+	c.mu.Lock()
+	if !c.fetchedAt.IsZero() && time.Since(c.fetchedAt) < c.ttl {
+		defer c.mu.Unlock()
+		return cloneCourses(c.courses), nil
+	}
+	c.mu.Unlock()
+
+	return c.fetch(ctx)
+}
+
+// Refresh fetches the catalog even when the cached copy is fresh, returning
+// the copy it replaced and when that copy was fetched, which is zero when
+// there was none.
+func (c *Catalog) Refresh(ctx context.Context) (previous CourseResponse, fetchedAt time.Time, current CourseResponse, err error) {
+	c.mu.Lock()
+	previous, fetchedAt = cloneCourses(c.courses), c.fetchedAt
+	c.mu.Unlock()
+
+	current, err = c.fetch(ctx)
+	if err != nil {
+		return CourseResponse{}, time.Time{}, CourseResponse{}, err
+	}
+
+	return previous, fetchedAt, current, nil
+}
+
+// fetch replaces the cached copy with the live catalog. A walk already in
+// flight is joined instead of starting another one, and ctx only bounds how
+// long the caller waits for it. The walk doesn't run with the values of ctx:
+// the copy it caches is shared by every caller, so it mustn't take the
+// locale or the token of the one that started it.
+func (c *Catalog) fetch(ctx context.Context) (CourseResponse, error) {
+	walk := c.fetches.DoChan("catalog", func() (any, error) {
+		ctx, cancel := context.WithTimeout(WithLocale(context.Background(), c.locale), catalogFetchTimeout)
+		defer cancel()
+		return c.walk(ctx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return CourseResponse{}, ctx.Err()
+	case result := <-walk:
+		if result.Err != nil {
+			return CourseResponse{}, result.Err
+		}
+		return cloneCourses(result.Val.(CourseResponse)), nil
+	}
+}
+
+// walk fetches the live catalog, hands it to the observers and caches it.
+// The result is shared by the callers of fetch, they clone it.
+func (c *Catalog) walk(ctx context.Context) (CourseResponse, error) {
+	c.mu.Lock()
+	size := len(c.courses.Data)
+	observers := slices.Clone(c.observers)
+	c.mu.Unlock()
+
+	courses, err := fetchCatalog(ctx, c.api, c.pageSize, size)
+	if err != nil {
+		return CourseResponse{}, err
+	}
+	for _, observer := range observers {
+		observer(ctx, cloneCourses(courses))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.courses = courses
+	c.fetchedAt = time.Now()
+	c.save()
+
+	return courses, nil
+}
+
+// cloneCourses copies the parts of the courses modified by the tools.
+func cloneCourses(r CourseResponse) CourseResponse {
+	data := append(r.Data[:0:0], r.Data...)
+	for i := range data {
+		data[i].CoursePrices = append(data[i].CoursePrices[:0:0], data[i].CoursePrices...)
+	}
+
+	return CourseResponse{Data: data}
+}
+
+func (c *Catalog) save() {
+	if c.path == "" {
+		return
+	}
+	data, err := json.Marshal(persistedCatalog{FetchedAt: c.fetchedAt, Courses: c.courses})
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(c.path), 0o700)
+	}
+	if err == nil {
+		err = os.WriteFile(c.path, data, 0o600)
+	}
+	if err != nil {
+		log.Printf("failed to persist the catalog: %v", err)
+	}
+}
+
+// fetchCatalog walks the catalog with room for size courses, the size of
+// the previous copy, so the slice isn't grown page after page.
+func fetchCatalog(ctx context.Context, api edteam.CoursesAPI, pageSize, size int) (CourseResponse, error) {
+	it := edteam.NewCourseIterator(api, 1, uint(pageSize))
+	it.MaxPages = maxCatalogPages
+
+	catalog := CourseResponse{Data: make([]Course, 0, size)}
+	courses, errs := it.Stream(ctx)
+	for course := range courses {
+		catalog.Data = append(catalog.Data, course)
+	}
+	if err := <-errs; err != nil {
+		return CourseResponse{}, err
+	}
+
+	return catalog, nil
+}
+
+// courseIndex returns the index of the course with the given id or -1.
+func courseIndex(courses CourseResponse, id int) int {
+	for i, item := range courses.Data {
+		if item.Course.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// courseSlugIndex returns the index of the course with the given slug or -1.
+func courseSlugIndex(courses CourseResponse, slug string) int {
+	for i, item := range courses.Data {
+		if item.Course.Slug == slug {
+			return i
+		}
+	}
+
+	return -1
+}
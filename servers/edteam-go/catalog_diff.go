@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// CatalogDiff compares the catalog cached by the server with the live one.
+type CatalogDiff struct {
+	// CachedAt is when the cached catalog was fetched, it is missing when
+	// there was no cached catalog and every course is reported as added.
+	CachedAt      *time.Time      `json:"cached_at,omitempty"`
+	CachedAtHuman string          `json:"cached_at_human,omitempty"`
+	Added         []DiffCourse    `json:"added"`
+	Removed       []DiffCourse    `json:"removed"`
+	Changed       []ChangedCourse `json:"changed"`
+	Message       string          `json:"message"`
+}
+
+type DiffCourse struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Level string `json:"level"`
+}
+
+type ChangedCourse struct {
+	DiffCourse
+	Changes []FieldChange `json:"changes"`
+}
+
+// FieldChange is a field of a course with its cached and live values. The
+// prices are named after their currency, e.g. price USD.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+func catalogDiff(cached CourseResponse, cachedAt time.Time, live CourseResponse, codes map[int]string, now time.Time, locale Locale) CatalogDiff {
+	diff := CatalogDiff{Added: []DiffCourse{}, Removed: []DiffCourse{}, Changed: []ChangedCourse{}}
+	if !cachedAt.IsZero() {
+		diff.CachedAt = &cachedAt
+		diff.CachedAtHuman = relativeTime(now, cachedAt, locale)
+	}
+
+	before := make(map[int]Course, len(cached.Data))
+	for _, item := range cached.Data {
+		before[item.Course.ID] = item
+	}
+	for _, item := range live.Data {
+		previous, ok := before[item.Course.ID]
+		delete(before, item.Course.ID)
+		if !ok {
+			diff.Added = append(diff.Added, diffCourse(item))
+			continue
+		}
+		if changes := courseChanges(previous, item, codes); len(changes) > 0 {
+			diff.Changed = append(diff.Changed, ChangedCourse{DiffCourse: diffCourse(item), Changes: changes})
+		}
+	}
+	for _, item := range before {
+		diff.Removed = append(diff.Removed, diffCourse(item))
+	}
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].ID < diff.Removed[j].ID })
+
+	if len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0 {
+		diff.Message = locale.T("catalog_diff_empty")
+	} else {
+		diff.Message = locale.T("catalog_diff", len(diff.Added), len(diff.Removed), len(diff.Changed))
+	}
+
+	return diff
+}
+
+func diffCourse(item Course) DiffCourse {
+	return DiffCourse{ID: item.Course.ID, Name: item.Course.Name, Slug: item.Course.Slug, Level: item.Course.Level}
+}
+
+// courseChanges returns the fields that matter to a buyer that changed
+// between the cached and the live course.
+func courseChanges(cached, live Course, codes map[int]string) []FieldChange {
+	var changes []FieldChange
+	add := func(field string, from, to any) {
+		if from != to {
+			changes = append(changes, FieldChange{Field: field, From: from, To: to})
+		}
+	}
+	add("name", cached.Course.Name, live.Course.Name)
+	add("slug", cached.Course.Slug, live.Course.Slug)
+	add("level", cached.Course.Level, live.Course.Level)
+	add("on_sale", cached.Course.OnSale, live.Course.OnSale)
+	add("visible", cached.Course.Visible, live.Course.Visible)
+
+	cachedPrices := make(map[int]CoursePrice, len(cached.CoursePrices))
+	for _, price := range cached.CoursePrices {
+		cachedPrices[price.CurrencyId] = price
+	}
+	livePrices := make(map[int]bool, len(live.CoursePrices))
+	for _, price := range live.CoursePrices {
+		livePrices[price.CurrencyId] = true
+		currency := currencyName(codes, price.CurrencyId)
+		previous, ok := cachedPrices[price.CurrencyId]
+		if !ok {
+			add("price "+currency, nil, price.Price)
+			continue
+		}
+		add("price "+currency, previous.Price, price.Price)
+		add("base_price "+currency, previous.BasePrice, price.BasePrice)
+	}
+	for _, price := range cached.CoursePrices {
+		if !livePrices[price.CurrencyId] {
+			add("price "+currencyName(codes, price.CurrencyId), price.Price, nil)
+		}
+	}
+
+	return changes
+}
+
+// currencyName is the code of the currency or its id when it has no code.
+func currencyName(codes map[int]string, currencyID int) string {
+	if code := codes[currencyID]; code != "" {
+		return code
+	}
+
+	return fmt.Sprintf("%d", currencyID)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+	"edteam-mcp/pkg/edteam/edteammock"
+)
+
+// TestCatalogFetchOutlivesCaller cancels the caller that started the walk of
+// the catalog: it returns at once, the walk goes on for the next caller.
+func TestCatalogFetchOutlivesCaller(t *testing.T) {
+	release := make(chan struct{})
+	api := &edteammock.CoursesAPIMock{
+		ListFunc: func(ctx context.Context, page, limit uint, opts ...edteam.CallOption) (edteam.CourseResponse, error) {
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return edteam.CourseResponse{}, ctx.Err()
+			}
+			return edteam.CourseResponse{Data: []Course{{Course: edteam.CourseDetails{ID: 101, Slug: "go-avanzado"}}}}, nil
+		},
+	}
+	catalog := NewCatalog(api, time.Hour, 50, LocaleEN)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := catalog.Courses(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Courses() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Courses() returned after %v, want it to stop waiting at the deadline", elapsed)
+	}
+
+	close(release)
+	courses, err := catalog.Courses(context.Background())
+	if err != nil {
+		t.Fatalf("Courses() error = %v", err)
+	}
+	if len(courses.Data) != 1 {
+		t.Errorf("Courses() returned %d courses, want 1", len(courses.Data))
+	}
+	// The second caller joined the walk of the first one.
+	if calls := len(api.ListCalls()); calls != 1 {
+		t.Errorf("the catalog was fetched with %d requests, want 1", calls)
+	}
+}
+
+// TestCatalogWalkKeepsItsLocale starts the walk from a caller in another
+// locale: the shared catalog is fetched in the locale of the catalog.
+func TestCatalogWalkKeepsItsLocale(t *testing.T) {
+	api := &edteammock.CoursesAPIMock{
+		ListFunc: func(ctx context.Context, page, limit uint, opts ...edteam.CallOption) (edteam.CourseResponse, error) {
+			if locale, _ := LocaleFromContext(ctx); locale != LocaleES {
+				t.Errorf("the catalog was fetched in %q, want %q", locale, LocaleES)
+			}
+			return edteam.CourseResponse{}, nil
+		},
+	}
+	catalog := NewCatalog(api, time.Hour, 50, LocaleES)
+
+	if _, err := catalog.Courses(WithLocale(context.Background(), LocaleEN)); err != nil {
+		t.Fatalf("Courses() error = %v", err)
+	}
+}
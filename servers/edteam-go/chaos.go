@@ -0,0 +1,88 @@
+package main
+
+import (
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Chaos injects failures into the requests to EDteam, to see the retries
+// and the error results work in tests and demos. It is off unless one of
+// the CHAOS_ variables is set.
+type Chaos struct {
+	// Latency is the maximum random delay added to every request.
+	Latency time.Duration
+	// ErrorRate is the fraction of the requests answered with a 503.
+	ErrorRate float64
+	// ResetRate is the fraction of the requests failing with a connection
+	// reset.
+	ResetRate float64
+}
+
+func (c Chaos) Enabled() bool {
+	return c.Latency > 0 || c.ErrorRate > 0 || c.ResetRate > 0
+}
+
+// chaosTransport applies Chaos to the requests sent through next.
+type chaosTransport struct {
+	chaos Chaos
+	next  http.RoundTripper
+}
+
+func newChaosTransport(chaos Chaos, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &chaosTransport{chaos: chaos, next: next}
+}
+
+const chaosBody = `{"messages":[{"title":"Service Unavailable","message":"failure injected by CHAOS_ERROR_RATE","code":"503"}]}`
+
+func (t *chaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.chaos.Latency > 0 {
+		timer := time.NewTimer(time.Duration(rand.Int64N(int64(t.chaos.Latency) + 1)))
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			closeRequestBody(req)
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	if rand.Float64() < t.chaos.ResetRate {
+		// A reset after the request was written, EDteam may have processed it.
+		closeRequestBody(req)
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	}
+	if rand.Float64() < t.chaos.ErrorRate {
+		closeRequestBody(req)
+		return &http.Response{
+			Status:        "503 Service Unavailable",
+			StatusCode:    http.StatusServiceUnavailable,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(strings.NewReader(chaosBody)),
+			ContentLength: int64(len(chaosBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// closeRequestBody closes the body of a request that isn't passed on, a
+// RoundTripper must close it even when it fails. The client returns its
+// pooled buffer once the body is closed.
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
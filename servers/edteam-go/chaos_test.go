@@ -0,0 +1,43 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type trackedBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+// TestChaosClosesRequestBody injects every failure: the body of the request
+// is closed even though it is never sent, like the RoundTripper contract asks.
+func TestChaosClosesRequestBody(t *testing.T) {
+	for name, chaos := range map[string]Chaos{
+		"connection reset": {ResetRate: 1},
+		"503":              {ErrorRate: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			body := &trackedBody{Reader: strings.NewReader(`{"course_id":101}`)}
+			req, err := http.NewRequest(http.MethodPost, "http://edteam.invalid/v2/private/shopping-carts", body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, _ := newChaosTransport(chaos, nil).RoundTrip(req)
+			if resp != nil {
+				resp.Body.Close()
+			}
+			if !body.closed {
+				t.Error("the request body wasn't closed")
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+// Loadtest calls a tool of the EDteam MCP server many times concurrently and
+// reports the throughput and the latency percentiles, to compare transport
+// and cache changes with numbers. It drives a running server over HTTP or
+// starts one over stdio:
+//
+//	go run ./cmd/loadtest -url http://localhost:8080/mcp -api-key secret -vary page=1-20
+//	go run ./cmd/loadtest -command ./edteam-mcp -tool Courses-List -concurrency 20
+//
+// Repeated calls with the same arguments are answered from the result cache
+// of the server, so the numbers would measure cache hits. The server started
+// with -command runs with RESULT_CACHE_TTL=0 unless -cache is set. A server
+// driven with -url keeps its own cache: start it with RESULT_CACHE_TTL=0, or
+// spread the calls over several arguments with -vary.
+//
+// Driving the server in-process is out of scope: the server is a main
+// package, which can't be imported.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type Options struct {
+	URL         string
+	APIKey      string
+	Command     string
+	Tool        string
+	Arguments   map[string]any
+	Vary        Vary
+	Cache       bool
+	Concurrency int
+	Requests    int
+	Timeout     time.Duration
+}
+
+// Vary changes the integer argument Name on every call, cycling from Min to
+// Max, so the calls don't repeat the same arguments. The zero value changes
+// nothing.
+type Vary struct {
+	Name     string
+	Min, Max int
+}
+
+// parseVary parses an argument and a range like page=1-20.
+func parseVary(value string) (Vary, error) {
+	if value == "" {
+		return Vary{}, nil
+	}
+
+	name, bounds, ok := strings.Cut(value, "=")
+	low, high, okRange := strings.Cut(bounds, "-")
+	minValue, errMin := strconv.Atoi(low)
+	maxValue, errMax := strconv.Atoi(high)
+	if !ok || !okRange || name == "" || errMin != nil || errMax != nil || minValue > maxValue {
+		return Vary{}, fmt.Errorf("-vary must be an argument and a range like page=1-20, got %q", value)
+	}
+
+	return Vary{Name: name, Min: minValue, Max: maxValue}, nil
+}
+
+// arguments returns the arguments of the i-th call.
+func (o Options) arguments(i int) map[string]any {
+	if o.Vary.Name == "" {
+		return o.Arguments
+	}
+
+	arguments := make(map[string]any, len(o.Arguments)+1)
+	for k, v := range o.Arguments {
+		arguments[k] = v
+	}
+	arguments[o.Vary.Name] = o.Vary.Min + i%(o.Vary.Max-o.Vary.Min+1)
+
+	return arguments
+}
+
+// Result holds the outcome of every call.
+type Result struct {
+	Latencies []time.Duration
+	Errors    int
+	Elapsed   time.Duration
+	// FirstError is kept to tell why the calls failed.
+	FirstError error
+}
+
+func main() {
+	var opts Options
+	var arguments, vary string
+	flag.StringVar(&opts.URL, "url", "", "streamable HTTP endpoint of a running server, e.g. http://localhost:8080/mcp")
+	flag.StringVar(&opts.APIKey, "api-key", os.Getenv("API_KEY"), "API key sent to the HTTP server")
+	flag.StringVar(&opts.Command, "command", "", "server binary to start and drive over stdio, it inherits the environment")
+	flag.StringVar(&opts.Tool, "tool", "Courses-List", "tool to call")
+	flag.StringVar(&arguments, "args", "{}", "JSON object with the arguments of the tool")
+	flag.StringVar(&vary, "vary", "", "integer argument changed on every call and its range, e.g. page=1-20")
+	flag.BoolVar(&opts.Cache, "cache", false, "keep the result cache of the server started with -command")
+	flag.IntVar(&opts.Concurrency, "concurrency", 10, "calls in flight at the same time")
+	flag.IntVar(&opts.Requests, "requests", 200, "total number of calls")
+	flag.DurationVar(&opts.Timeout, "timeout", 30*time.Second, "timeout of every call")
+	flag.Parse()
+
+	if err := json.Unmarshal([]byte(arguments), &opts.Arguments); err != nil {
+		fmt.Fprintf(os.Stderr, "-args must be a JSON object: %v\n", err)
+		os.Exit(2)
+	}
+	var err error
+	if opts.Vary, err = parseVary(vary); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if (opts.URL == "") == (opts.Command == "") {
+		fmt.Fprintln(os.Stderr, "set either -url or -command")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if opts.Concurrency < 1 || opts.Requests < 1 {
+		fmt.Fprintln(os.Stderr, "-concurrency and -requests must be greater than 0")
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	c, err := connect(ctx, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer c.Close()
+
+	result := run(ctx, c, opts)
+	report(os.Stdout, opts, result)
+	if result.Errors == len(result.Latencies) {
+		os.Exit(1)
+	}
+}
+
+func connect(ctx context.Context, opts Options) (*client.Client, error) {
+	var c *client.Client
+	var err error
+	if opts.URL != "" {
+		var httpOpts []transport.StreamableHTTPCOption
+		if opts.APIKey != "" {
+			httpOpts = append(httpOpts, transport.WithHTTPHeaders(map[string]string{"X-API-Key": opts.APIKey}))
+		}
+		c, err = client.NewStreamableHttpClient(opts.URL, httpOpts...)
+		if err == nil {
+			err = c.Start(ctx)
+		}
+	} else {
+		env := os.Environ()
+		if !opts.Cache {
+			// Appended last, it wins over the value of the environment.
+			env = append(env, "RESULT_CACHE_TTL=0", "RESULT_CACHE_TTLS=")
+		}
+		fields := strings.Fields(opts.Command)
+		c, err = client.NewStdioMCPClient(fields[0], env, fields[1:]...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	initialize := mcp.InitializeRequest{}
+	initialize.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initialize.Params.ClientInfo = mcp.Implementation{Name: "edteam-loadtest", Version: "1.0.0"}
+	if _, err := c.Initialize(ctx, initialize); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to initialize: %w", err)
+	}
+
+	return c, nil
+}
+
+// run sends opts.Requests calls with opts.Concurrency workers.
+func run(ctx context.Context, c *client.Client, opts Options) Result {
+	jobs := make(chan int)
+	var mu sync.Mutex
+	var result Result
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for range opts.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				latency, err := call(ctx, c, opts.Tool, opts.arguments(i), opts.Timeout)
+				mu.Lock()
+				result.Latencies = append(result.Latencies, latency)
+				if err != nil {
+					result.Errors++
+					if result.FirstError == nil {
+						result.FirstError = err
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range opts.Requests {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	result.Elapsed = time.Since(start)
+
+	return result
+}
+
+func call(ctx context.Context, c *client.Client, tool string, arguments map[string]any, timeout time.Duration) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = tool
+	request.Params.Arguments = arguments
+
+	start := time.Now()
+	result, err := c.CallTool(ctx, request)
+	latency := time.Since(start)
+	if err == nil && result.IsError {
+		err = errors.New(toolError(result))
+	}
+
+	return latency, err
+}
+
+func toolError(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+
+	return "the tool returned an error"
+}
+
+func report(w io.Writer, opts Options, result Result) {
+	latencies := slices.Clone(result.Latencies)
+	slices.Sort(latencies)
+
+	fmt.Fprintf(w, "tool:        %s\n", opts.Tool)
+	fmt.Fprintf(w, "calls:       %d (%d failed)\n", len(latencies), result.Errors)
+	fmt.Fprintf(w, "concurrency: %d\n", opts.Concurrency)
+	if opts.Vary.Name != "" {
+		fmt.Fprintf(w, "vary:        %s=%d-%d\n", opts.Vary.Name, opts.Vary.Min, opts.Vary.Max)
+	}
+	fmt.Fprintf(w, "elapsed:     %s\n", result.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "throughput:  %.1f calls/s\n", float64(len(latencies))/result.Elapsed.Seconds())
+	for _, p := range []float64{50, 90, 99} {
+		fmt.Fprintf(w, "%-13s%s\n", fmt.Sprintf("p%.0f:", p), percentile(latencies, p).Round(time.Microsecond))
+	}
+	fmt.Fprintf(w, "max:         %s\n", latencies[len(latencies)-1].Round(time.Microsecond))
+	if result.FirstError != nil {
+		fmt.Fprintf(w, "first error: %v\n", result.FirstError)
+	}
+}
+
+// percentile returns the p-th percentile of the sorted latencies using the
+// nearest rank.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+
+	return sorted[rank]
+}
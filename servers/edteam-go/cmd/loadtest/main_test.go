@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestParseVary(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Vary
+		wantErr bool
+	}{
+		{value: "", want: Vary{}},
+		{value: "page=1-20", want: Vary{Name: "page", Min: 1, Max: 20}},
+		{value: "page=3-3", want: Vary{Name: "page", Min: 3, Max: 3}},
+		{value: "page=20-1", wantErr: true},
+		{value: "page", wantErr: true},
+		{value: "=1-2", wantErr: true},
+		{value: "page=a-2", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseVary(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseVary(%q) = %+v, %v, want %+v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestOptionsArgumentsVary(t *testing.T) {
+	opts := Options{Arguments: map[string]any{"limit": 10.0}, Vary: Vary{Name: "page", Min: 1, Max: 3}}
+	for i, want := range []int{1, 2, 3, 1} {
+		arguments := opts.arguments(i)
+		if arguments["page"] != want || arguments["limit"] != 10.0 {
+			t.Errorf("arguments(%d) = %v, want page %d", i, arguments, want)
+		}
+	}
+	if _, ok := opts.Arguments["page"]; ok {
+		t.Error("arguments() changed the shared arguments")
+	}
+}
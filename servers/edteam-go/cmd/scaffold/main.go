@@ -0,0 +1,179 @@
+// Scaffold generates the skeleton of a new MCP server (main, config, tool
+// registry, handler stubs and tests) so every lesson starts from the same
+// boilerplate. Run it from the edteam-go directory:
+//
+//	go run ./cmd/scaffold -name weather-go -tools Forecast,Alerts
+package main
+
+import (
+	"bytes"
+	"embed"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// mcpGoVersion is the mcp-go version the generated servers depend on. Keep it
+// in sync with the one used by edteam-go so every lesson shares the same API.
+const mcpGoVersion = "v0.45.0"
+
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+type Tool struct {
+	Name        string
+	HandlerName string
+}
+
+type Data struct {
+	Name         string
+	Module       string
+	Title        string
+	MCPGoVersion string
+	Tools        []Tool
+}
+
+func main() {
+	name := flag.String("name", "", "Name of the new server, e.g. weather-go")
+	tools := flag.String("tools", "", "Comma separated list of tool names, e.g. Forecast,Alerts")
+	dir := flag.String("dir", "..", "Directory where the server will be created")
+	module := flag.String("module", "", "Go module path (defaults to the server name)")
+	flag.Parse()
+
+	data, err := newData(*name, *module, *tools)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	target := filepath.Join(*dir, data.Name)
+	if err := generate(target, data); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Server %q created in %s\n", data.Name, target)
+}
+
+func newData(name, module, tools string) (Data, error) {
+	if !namePattern.MatchString(name) {
+		return Data{}, fmt.Errorf("invalid name %q: use lowercase letters, digits and dashes", name)
+	}
+	if module == "" {
+		module = name
+	}
+
+	data := Data{
+		Name:         name,
+		Module:       module,
+		Title:        title(name),
+		MCPGoVersion: mcpGoVersion,
+	}
+
+	seen := make(map[string]bool)
+	for _, toolName := range strings.Split(tools, ",") {
+		toolName = strings.TrimSpace(toolName)
+		if toolName == "" {
+			continue
+		}
+		handlerName := "handle" + identifier(toolName)
+		if handlerName == "handle" {
+			return Data{}, fmt.Errorf("invalid tool name %q", toolName)
+		}
+		if seen[handlerName] {
+			return Data{}, fmt.Errorf("duplicated tool name %q", toolName)
+		}
+		seen[handlerName] = true
+		data.Tools = append(data.Tools, Tool{Name: toolName, HandlerName: handlerName})
+	}
+	if len(data.Tools) == 0 {
+		return Data{}, fmt.Errorf("at least one tool is required")
+	}
+
+	return data, nil
+}
+
+func generate(target string, data Data) error {
+	if _, err := os.Stat(target); err == nil {
+		return fmt.Errorf("%s already exists", target)
+	}
+
+	entries, err := templates.ReadDir("templates")
+	if err != nil {
+		return fmt.Errorf("failed to read templates: %w", err)
+	}
+
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		tmpl, err := template.ParseFS(templates, "templates/"+entry.Name())
+		if err != nil {
+			return fmt.Errorf("failed to parse template %s: %w", entry.Name(), err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to execute template %s: %w", entry.Name(), err)
+		}
+
+		fileName := strings.TrimSuffix(entry.Name(), ".tmpl")
+		content := buf.Bytes()
+		if strings.HasSuffix(fileName, ".go") {
+			content, err = format.Source(content)
+			if err != nil {
+				return fmt.Errorf("failed to format %s: %w", fileName, err)
+			}
+		}
+
+		if err := os.WriteFile(filepath.Join(target, fileName), content, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", fileName, err)
+		}
+	}
+
+	return nil
+}
+
+// identifier converts a tool name like "Courses-List" into "CoursesList".
+func identifier(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if b.Len() == 0 && unicode.IsDigit(r) {
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// title converts a server name like "weather-go" into "Weather Go".
+func title(name string) string {
+	words := strings.Split(name, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func generateTemp(t *testing.T) string {
+	t.Helper()
+
+	data, err := newData("weather-go", "", "Forecast, Weather-Alerts")
+	if err != nil {
+		t.Fatalf("newData() error = %v", err)
+	}
+	target := filepath.Join(t.TempDir(), data.Name)
+	if err := generate(target, data); err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+
+	return target
+}
+
+// TestGenerate renders every template and checks the Go files are
+// formatted like gofmt would.
+func TestGenerate(t *testing.T) {
+	target := generateTemp(t)
+
+	for _, name := range []string{"config.go", "go.mod", "handlers.go", "handlers_test.go", "main.go", "tools.go"} {
+		content, err := os.ReadFile(filepath.Join(target, name))
+		if err != nil {
+			t.Fatalf("%s wasn't generated: %v", name, err)
+		}
+		if !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		formatted, err := format.Source(content)
+		if err != nil {
+			t.Fatalf("%s isn't valid Go: %v", name, err)
+		}
+		if !bytes.Equal(content, formatted) {
+			t.Errorf("%s isn't gofmt formatted", name)
+		}
+	}
+
+	handlers, _ := os.ReadFile(filepath.Join(target, "handlers.go"))
+	for _, handler := range []string{"handleForecast", "handleWeatherAlerts"} {
+		if !bytes.Contains(handlers, []byte("func "+handler+"(")) {
+			t.Errorf("handlers.go has no %s", handler)
+		}
+	}
+
+	if err := generate(target, Data{}); err == nil {
+		t.Error("generate() overwrote an existing directory")
+	}
+}
+
+// TestMCPGoVersion checks the generated servers depend on the mcp-go version
+// of edteam-go.
+func TestMCPGoVersion(t *testing.T) {
+	content, err := os.ReadFile("../../go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "github.com/mark3labs/mcp-go" {
+			if fields[1] != mcpGoVersion {
+				t.Errorf("mcpGoVersion = %s, edteam-go uses %s", mcpGoVersion, fields[1])
+			}
+			return
+		}
+	}
+	t.Error("edteam-go doesn't require mcp-go")
+}
+
+// TestGeneratedServerBuilds vets and runs the tests of a generated server
+// against the mcp-go of edteam-go, checked with its go.sum. The modules
+// missing from the module cache are downloaded.
+func TestGeneratedServerBuilds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the generated server")
+	}
+
+	target := generateTemp(t)
+	sum, err := os.ReadFile("../../go.sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "go.sum"), sum, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{{"vet", "./..."}, {"test", "./..."}} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = target
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go %s failed: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+}
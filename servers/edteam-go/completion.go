@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Completions completes the slug and query arguments of the prompts and the
+// resource templates from the search index: a slug completes to the slugs
+// of the matching courses and a query to their names.
+type Completions struct {
+	index *SearchIndex
+}
+
+var (
+	_ server.PromptCompletionProvider   = (*Completions)(nil)
+	_ server.ResourceCompletionProvider = (*Completions)(nil)
+)
+
+func NewCompletions(index *SearchIndex) *Completions {
+	return &Completions{index: index}
+}
+
+func (c *Completions) CompletePromptArgument(ctx context.Context, promptName string, argument mcp.CompleteArgument, _ mcp.CompleteContext) (*mcp.Completion, error) {
+	return c.complete(ctx, argument)
+}
+
+func (c *Completions) CompleteResourceArgument(ctx context.Context, uri string, argument mcp.CompleteArgument, _ mcp.CompleteContext) (*mcp.Completion, error) {
+	return c.complete(ctx, argument)
+}
+
+func (c *Completions) complete(ctx context.Context, argument mcp.CompleteArgument) (*mcp.Completion, error) {
+	if argument.Name != "slug" && argument.Name != "query" {
+		return &mcp.Completion{Values: []string{}}, nil
+	}
+
+	results, total, err := c.index.Search(ctx, argument.Value, maxCompletions)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, len(results))
+	for _, result := range results {
+		if argument.Name == "slug" {
+			values = append(values, result.Slug)
+		} else {
+			values = append(values, result.Name)
+		}
+	}
+
+	return &mcp.Completion{Values: values, Total: total, HasMore: total > len(values)}, nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrBusy is returned when a call waited QUEUE_TIMEOUT for a free slot.
+var ErrBusy = errors.New("too many tool calls in progress, the call waited in the queue and timed out")
+
+// CallSlots bounds the tool calls running at the same time, the rest wait
+// in a queue. It is created once, so reloading the config doesn't reset it.
+type CallSlots struct {
+	slots   chan struct{}
+	timeout time.Duration
+}
+
+// NewCallSlots allows limit calls at the same time, each call waits up to
+// timeout for a slot. A limit of zero disables it.
+func NewCallSlots(limit int, timeout time.Duration) *CallSlots {
+	if limit <= 0 {
+		return &CallSlots{}
+	}
+
+	return &CallSlots{slots: make(chan struct{}, limit), timeout: timeout}
+}
+
+// acquire waits for a slot and returns the function releasing it.
+func (c *CallSlots) acquire(ctx context.Context) (func(), error) {
+	if c.slots == nil {
+		return func() {}, nil
+	}
+
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+	select {
+	case c.slots <- struct{}{}:
+		return func() { <-c.slots }, nil
+	case <-timer.C:
+		return nil, ErrBusy
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func limitConcurrency(locale Locale, slots *CallSlots) ToolMiddleware {
+	return func(tool mcp.Tool, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			release, err := slots.acquire(ctx)
+			if err != nil {
+				return toolErrorResult(err, NewArgs(request.GetArguments()).Locale(locale)), nil
+			}
+			defer release()
+
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,520 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+	"edteam-mcp/vcr"
+)
+
+type Config struct {
+	Email    string
+	Password string
+	Locale   Locale
+	// ToolLocale is the language of the tool descriptions sent to the
+	// client, English by default.
+	ToolLocale Locale
+
+	Transport string
+	Listen    string
+	LogLevel  string
+
+	// APIKey is required from the clients of the sse and http transports.
+	APIKey string
+
+	// AllowedOrigins are the browser origins accepted by the sse and http
+	// transports besides the loopback ones, "*" accepts any.
+	AllowedOrigins []string
+
+	// MultiTenant serves several users from one process over HTTP, each
+	// session sends its own EDteam token instead of using EMAIL and PASSWORD.
+	MultiTenant bool
+
+	DefaultPageSize int
+	MaxPageSize     int
+
+	// CatalogTTL is how long the full catalog is kept in memory.
+	CatalogTTL time.Duration
+
+	CurrencyCodes map[int]string
+	// CurrencyRates is a JSON file or URL with the exchange rates used to
+	// convert prices, conversion is disabled when empty.
+	CurrencyRates string
+
+	// EDteamBaseURL sends the requests to EDteam to another host keeping
+	// their paths, like to a fake EDteam or a proxy.
+	EDteamBaseURL string
+
+	// ToolTimeout bounds every tool call, zero disables it.
+	ToolTimeout time.Duration
+
+	// Retry is the policy of the failed requests to EDteam.
+	Retry edteam.RetryPolicy
+
+	// MaxConcurrentCalls bounds the tool calls running at the same time, so
+	// a burst of calls doesn't open dozens of connections to EDteam. The
+	// rest wait up to QueueTimeout, zero disables the limit.
+	MaxConcurrentCalls int
+	QueueTimeout       time.Duration
+
+	// HTTP tunes the connections to EDteam.
+	HTTP HTTPTransport
+	// MaxResponseBytes bounds the responses of EDteam, zero doesn't.
+	MaxResponseBytes int
+
+	// ResultCacheTTL is how long the results of the read-only tools are
+	// reused for identical calls, zero disables the cache. ResultCacheTTLs
+	// override it by tool.
+	ResultCacheTTL  time.Duration
+	ResultCacheTTLs []ToolTTL
+
+	// RateLimits bound how often the tools are called for every account,
+	// the one of the server or the tenant of the token, the first limit
+	// matching a tool applies.
+	RateLimits []RateLimit
+
+	// ConfirmSideEffects asks the user to approve the calls of the tools that
+	// change something in EDteam, like adding a course to the cart.
+	// ConfirmTools are more tools to approve, names or patterns.
+	ConfirmSideEffects bool
+	ConfirmTools       []string
+
+	// SpendingCap and DataRetention are part of the policy announced to the
+	// clients, DataRetention replaces the description of what the server
+	// keeps in DataDir. Shopping-Cart-Add-Course and Gift-Course refuse the
+	// courses that go over SpendingCap.
+	SpendingCap   SpendingCap
+	DataRetention string
+
+	// DriftWarnings adds a warning to the tool results when EDteam returns
+	// fields unknown to the models.
+	DriftWarnings bool
+
+	// EnabledTools and DisabledTools choose the tools exposed by the server,
+	// by name or pattern like Shopping-*.
+	EnabledTools  []string
+	DisabledTools []string
+
+	// ReadOnly removes every tool with side effects.
+	ReadOnly bool
+
+	// SyncInterval is how often the catalog is refreshed in the background
+	// to find the watched courses on sale, zero disables the sync.
+	// SyncSchedule, a cron expression, replaces it.
+	SyncInterval time.Duration
+	SyncSchedule *Cron
+	// DigestSchedule is when the courses and articles published since the
+	// previous digest are notified, nil disables the digest.
+	DigestSchedule *Cron
+	// ScheduleJitter is the maximum random delay of the scheduled tasks.
+	ScheduleJitter time.Duration
+	// FollowedTopics are the words, like go or docker, that make the sync
+	// notify a new course having them in its name or subtitle.
+	FollowedTopics []string
+	// SubscriptionWarning is how long before the subscription ends the sync
+	// notifies it, zero disables it.
+	SubscriptionWarning time.Duration
+
+	// Daemon persists the token and the catalog in DataDir so a restart
+	// doesn't start cold, and writes PIDFile.
+	Daemon  bool
+	PIDFile string
+	// TokenPassphrase encrypts the token persisted by the daemon, without it
+	// the key is kept in the OS keyring.
+	TokenPassphrase string
+
+	// VCRMode records the traffic with EDteam into VCRCassette or, in
+	// replay mode, answers from it without network.
+	VCRMode     string
+	VCRCassette string
+
+	// Telemetry serves the metrics. Off, it also refuses every request to a
+	// host other than EDteam.
+	Telemetry bool
+
+	// Chaos injects latency and failures into the requests to EDteam.
+	Chaos Chaos
+
+	// DataDir keeps the state persisted between runs, like the catalog
+	// snapshot used by Whats-New.
+	DataDir string
+}
+
+// ConfigError lists every problem found in the configuration, so all of
+// them can be fixed at once instead of one run at a time.
+type ConfigError struct {
+	Problems []string
+}
+
+func (e *ConfigError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// add appends err to the problems, flattening the ones of another
+// ConfigError. Nil errors are ignored.
+func (e *ConfigError) add(err error) {
+	var configErr *ConfigError
+	switch {
+	case err == nil:
+	case errors.As(err, &configErr):
+		e.Problems = append(e.Problems, configErr.Problems...)
+	default:
+		e.Problems = append(e.Problems, err.Error())
+	}
+}
+
+// err returns e when it holds any problem.
+func (e *ConfigError) err() error {
+	if len(e.Problems) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// LoadConfig reads the configuration from the environment. The credentials
+// are checked later by validateConfig, they depend on the exposed tools.
+func LoadConfig() (Config, error) {
+	cfg := Config{
+		Email:    os.Getenv("EMAIL"),
+		Password: os.Getenv("PASSWORD"),
+		Locale:   LocaleES,
+
+		ToolLocale: LocaleEN,
+
+		Transport: envString("TRANSPORT", TransportStdio),
+		Listen:    envString("LISTEN", "127.0.0.1:8080"),
+		LogLevel:  envString("LOG_LEVEL", "info"),
+		APIKey:    os.Getenv("API_KEY"),
+
+		DefaultPageSize: 10,
+		MaxPageSize:     10,
+
+		CatalogTTL: 10 * time.Minute,
+
+		SyncInterval:        30 * time.Minute,
+		SubscriptionWarning: 7 * 24 * time.Hour,
+		ScheduleJitter:      time.Minute,
+
+		ToolTimeout: time.Minute,
+		Retry:       edteam.DefaultRetryPolicy,
+
+		ResultCacheTTL: time.Minute,
+
+		MaxConcurrentCalls: 8,
+		QueueTimeout:       10 * time.Second,
+
+		HTTP:             defaultHTTPTransport,
+		MaxResponseBytes: edteam.DefaultMaxResponseSize,
+	}
+	var problems ConfigError
+	var err error
+	cfg.MultiTenant, err = envBool("MULTI_TENANT", false)
+	problems.add(err)
+
+	if value := os.Getenv("LOCALE"); value != "" {
+		locale, ok := ParseLocale(value)
+		if ok {
+			cfg.Locale = locale
+		} else {
+			problems.add(fmt.Errorf("unsupported LOCALE %q, use one of: %s, %s", value, LocaleES, LocaleEN))
+		}
+	}
+
+	if value := os.Getenv("TOOL_LOCALE"); value != "" {
+		locale, ok := ParseLocale(value)
+		if ok {
+			cfg.ToolLocale = locale
+		} else {
+			problems.add(fmt.Errorf("unsupported TOOL_LOCALE %q, use one of: %s, %s", value, LocaleES, LocaleEN))
+		}
+	}
+
+	var errDefault, errMax error
+	cfg.DefaultPageSize, errDefault = envInt("DEFAULT_PAGE_SIZE", cfg.DefaultPageSize)
+	problems.add(errDefault)
+	cfg.MaxPageSize, errMax = envInt("MAX_PAGE_SIZE", cfg.MaxPageSize)
+	problems.add(errMax)
+	switch {
+	case errDefault != nil || errMax != nil:
+	case cfg.MaxPageSize < 1:
+		problems.add(fmt.Errorf("MAX_PAGE_SIZE must be greater than 0"))
+	case cfg.DefaultPageSize < 1 || cfg.DefaultPageSize > cfg.MaxPageSize:
+		problems.add(fmt.Errorf("DEFAULT_PAGE_SIZE must be between 1 and MAX_PAGE_SIZE (%d)", cfg.MaxPageSize))
+	}
+
+	cfg.CatalogTTL, err = envDuration("CATALOG_TTL", cfg.CatalogTTL)
+	problems.add(err)
+
+	cfg.CurrencyCodes, err = parseCurrencyCodes(os.Getenv("CURRENCY_CODES"))
+	problems.add(err)
+	cfg.CurrencyRates = os.Getenv("CURRENCY_RATES")
+	cfg.EDteamBaseURL = os.Getenv("EDTEAM_BASE_URL")
+
+	cfg.ToolTimeout, err = envDuration("TOOL_TIMEOUT", cfg.ToolTimeout)
+	problems.add(err)
+
+	cfg.Retry.MaxAttempts, err = envInt("RETRY_MAX_ATTEMPTS", cfg.Retry.MaxAttempts)
+	problems.add(err)
+	if cfg.Retry.MaxAttempts < 1 {
+		problems.add(errors.New("RETRY_MAX_ATTEMPTS must be 1 or greater"))
+	}
+	cfg.Retry.BaseDelay, err = envDuration("RETRY_BASE_DELAY", cfg.Retry.BaseDelay)
+	problems.add(err)
+	cfg.Retry.MaxDelay, err = envDuration("RETRY_MAX_DELAY", cfg.Retry.MaxDelay)
+	problems.add(err)
+	if cfg.Retry.BaseDelay > cfg.Retry.MaxDelay {
+		problems.add(errors.New("RETRY_BASE_DELAY can't be greater than RETRY_MAX_DELAY"))
+	}
+	cfg.Retry.RetryableStatus, err = parseStatusCodes(envList("RETRY_STATUS"))
+	problems.add(err)
+	cfg.Retry.Methods, err = parseRetryMethods(envList("RETRY_METHODS"), cfg.Retry)
+	problems.add(err)
+
+	cfg.MaxConcurrentCalls, err = envInt("MAX_CONCURRENT_CALLS", cfg.MaxConcurrentCalls)
+	problems.add(err)
+	if cfg.MaxConcurrentCalls < 0 {
+		problems.add(errors.New("MAX_CONCURRENT_CALLS must be 0 or greater"))
+	}
+	cfg.QueueTimeout, err = envDuration("QUEUE_TIMEOUT", cfg.QueueTimeout)
+	problems.add(err)
+
+	cfg.HTTP.MaxIdleConnsPerHost, err = envInt("HTTP_MAX_IDLE_CONNS_PER_HOST", cfg.HTTP.MaxIdleConnsPerHost)
+	problems.add(err)
+	if cfg.HTTP.MaxIdleConnsPerHost < 1 {
+		problems.add(errors.New("HTTP_MAX_IDLE_CONNS_PER_HOST must be greater than 0"))
+	}
+	cfg.HTTP.MaxConnsPerHost, err = envInt("HTTP_MAX_CONNS_PER_HOST", cfg.HTTP.MaxConnsPerHost)
+	problems.add(err)
+	if cfg.HTTP.MaxConnsPerHost < 0 {
+		problems.add(errors.New("HTTP_MAX_CONNS_PER_HOST must be 0 or greater"))
+	}
+	cfg.HTTP.IdleConnTimeout, err = envDuration("HTTP_IDLE_CONN_TIMEOUT", cfg.HTTP.IdleConnTimeout)
+	problems.add(err)
+	cfg.HTTP.HTTP2, err = envBool("HTTP2", cfg.HTTP.HTTP2)
+	problems.add(err)
+	cfg.MaxResponseBytes, err = envInt("MAX_RESPONSE_BYTES", cfg.MaxResponseBytes)
+	problems.add(err)
+	if cfg.MaxResponseBytes < 0 {
+		problems.add(errors.New("MAX_RESPONSE_BYTES must be 0 or greater"))
+	}
+
+	cfg.ResultCacheTTL, err = envDuration("RESULT_CACHE_TTL", cfg.ResultCacheTTL)
+	problems.add(err)
+	cfg.ResultCacheTTLs, err = parseToolTTLs(envString("RESULT_CACHE_TTLS", defaultResultCacheTTLs))
+	problems.add(err)
+
+	cfg.RateLimits, err = parseRateLimits(envString("TOOL_RATE_LIMITS", defaultRateLimits))
+	problems.add(err)
+
+	cfg.DriftWarnings, err = envBool("SCHEMA_DRIFT_WARNINGS", false)
+	problems.add(err)
+
+	cfg.ConfirmSideEffects, err = envBool("CONFIRM_SIDE_EFFECTS", true)
+	problems.add(err)
+	cfg.ConfirmTools, err = parseToolPatterns("CONFIRM_TOOLS", envList("CONFIRM_TOOLS"))
+	problems.add(err)
+
+	cfg.SpendingCap, err = parseSpendingCap(os.Getenv("POLICY_SPENDING_CAP"))
+	problems.add(err)
+	cfg.DataRetention = envString("POLICY_DATA_RETENTION", "")
+
+	cfg.AllowedOrigins = envList("ALLOWED_ORIGINS")
+
+	cfg.EnabledTools = envList("ENABLED_TOOLS")
+	cfg.DisabledTools = envList("DISABLED_TOOLS")
+
+	cfg.ReadOnly, err = envBool("READ_ONLY", false)
+	problems.add(err)
+
+	cfg.SyncInterval, err = envDuration("SYNC_INTERVAL", cfg.SyncInterval)
+	problems.add(err)
+	cfg.SyncSchedule, err = envCron("SYNC_SCHEDULE")
+	problems.add(err)
+	cfg.DigestSchedule, err = envCron("DIGEST_SCHEDULE")
+	problems.add(err)
+	cfg.ScheduleJitter, err = envDuration("SCHEDULE_JITTER", cfg.ScheduleJitter)
+	problems.add(err)
+	cfg.FollowedTopics = envList("FOLLOWED_TOPICS")
+	cfg.SubscriptionWarning, err = envDuration("SUBSCRIPTION_WARNING", cfg.SubscriptionWarning)
+	problems.add(err)
+
+	cfg.Daemon, err = envBool("DAEMON", false)
+	problems.add(err)
+	cfg.PIDFile = os.Getenv("PID_FILE")
+	cfg.TokenPassphrase = os.Getenv("TOKEN_PASSPHRASE")
+
+	cfg.VCRMode = os.Getenv("VCR_MODE")
+	cfg.VCRCassette = os.Getenv("VCR_CASSETTE")
+	if cfg.VCRMode != "" && cfg.VCRMode != vcr.ModeRecord && cfg.VCRMode != vcr.ModeReplay {
+		problems.add(fmt.Errorf("unsupported VCR_MODE %q, use %s or %s", cfg.VCRMode, vcr.ModeRecord, vcr.ModeReplay))
+	}
+	if cfg.VCRMode != "" && cfg.VCRCassette == "" {
+		problems.add(errors.New("VCR_CASSETTE must be set when VCR_MODE is set"))
+	}
+
+	cfg.Telemetry, err = envSwitch("TELEMETRY", true)
+	problems.add(err)
+
+	cfg.Chaos.Latency, err = envDuration("CHAOS_LATENCY", 0)
+	problems.add(err)
+	cfg.Chaos.ErrorRate, err = envRate("CHAOS_ERROR_RATE")
+	problems.add(err)
+	cfg.Chaos.ResetRate, err = envRate("CHAOS_RESET_RATE")
+	problems.add(err)
+
+	cfg.DataDir = os.Getenv("DATA_DIR")
+	if cfg.DataDir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			problems.add(fmt.Errorf("DATA_DIR is not set and there is no cache directory: %w", err))
+		} else {
+			cfg.DataDir = filepath.Join(cacheDir, "edteam-mcp")
+		}
+	}
+	if cfg.Daemon && cfg.PIDFile == "" && cfg.DataDir != "" {
+		cfg.PIDFile = filepath.Join(cfg.DataDir, "edteam-mcp.pid")
+	}
+
+	return cfg, problems.err()
+}
+
+// parseStatusCodes parses the status codes of RETRY_STATUS, nil keeps the
+// default ones.
+func parseStatusCodes(values []string) ([]int, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	codes := make([]int, 0, len(values))
+	for _, value := range values {
+		code, err := strconv.Atoi(value)
+		if err != nil || code < 400 || code > 599 {
+			return nil, fmt.Errorf("invalid status code %q in RETRY_STATUS, use error codes like 429,503", value)
+		}
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+// parseRetryMethods parses a list like "POST=1,PUT=2" into the policies of
+// those methods, base with their max attempts.
+func parseRetryMethods(items []string, base edteam.RetryPolicy) (map[string]edteam.RetryPolicy, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	methods := make(map[string]edteam.RetryPolicy, len(items))
+	for _, item := range items {
+		method, rawAttempts, ok := strings.Cut(item, "=")
+		method = strings.ToUpper(strings.TrimSpace(method))
+		attempts, err := strconv.Atoi(strings.TrimSpace(rawAttempts))
+		if !ok || method == "" || err != nil || attempts < 1 {
+			return nil, fmt.Errorf("invalid retry override %q in RETRY_METHODS, use METHOD=attempts like POST=1", item)
+		}
+		policy := base
+		policy.MaxAttempts = attempts
+		methods[method] = policy
+	}
+
+	return methods, nil
+}
+
+func envString(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+// envList splits a comma separated variable, skipping empty elements.
+func envList(name string) []string {
+	var list []string
+	for _, value := range strings.Split(os.Getenv(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			list = append(list, value)
+		}
+	}
+
+	return list
+}
+
+func envInt(name string, fallback int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number: %w", name, err)
+	}
+
+	return n, nil
+}
+
+func envBool(name string, fallback bool) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean: %w", name, err)
+	}
+
+	return b, nil
+}
+
+func envDuration(name string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration like 10m: %w", name, err)
+	}
+
+	return d, nil
+}
+
+// envCron reads a cron expression, nil when the variable is empty.
+func envCron(name string) (*Cron, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	c, err := ParseCron(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return c, nil
+}
+
+// envRate reads a fraction between 0 and 1, like 0.2 for 20%.
+func envRate(name string) (float64, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("%s must be a number between 0 and 1, got %q", name, value)
+	}
+
+	return rate, nil
+}
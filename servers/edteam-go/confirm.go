@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Confirmation is the answer of the user to an action that needs approval.
+type Confirmation int
+
+const (
+	// ConfirmationAccepted means the user approved the action.
+	ConfirmationAccepted Confirmation = iota
+	// ConfirmationDeclined means the user rejected or dismissed the action.
+	ConfirmationDeclined
+	// ConfirmationRequired means the client can't ask the user and the tool
+	// was called without confirm, the assistant has to ask and call again.
+	ConfirmationRequired
+)
+
+// confirmAction asks the user to approve message through elicitation. The
+// confirm argument of the tool is only trusted when the client doesn't
+// support elicitation, otherwise the assistant could approve on its own.
+func confirmAction(ctx context.Context, s *server.MCPServer, message string, confirmed bool) (Confirmation, error) {
+	if !supportsElicitation(ctx) {
+		if confirmed {
+			return ConfirmationAccepted, nil
+		}
+		return ConfirmationRequired, nil
+	}
+
+	result, err := s.RequestElicitation(ctx, mcp.ElicitationRequest{
+		Params: mcp.ElicitationParams{
+			Message: message,
+			RequestedSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"confirm": map[string]any{
+						"type":  "boolean",
+						"title": message,
+					},
+				},
+				"required": []string{"confirm"},
+			},
+		},
+	})
+	if err != nil {
+		return ConfirmationDeclined, err
+	}
+	if result.Action != mcp.ElicitationResponseActionAccept {
+		return ConfirmationDeclined, nil
+	}
+	content, _ := result.Content.(map[string]any)
+	if approved, _ := content["confirm"].(bool); !approved {
+		return ConfirmationDeclined, nil
+	}
+
+	return ConfirmationAccepted, nil
+}
+
+func supportsElicitation(ctx context.Context) bool {
+	session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo)
+	if !ok {
+		return false
+	}
+
+	return session.GetClientCapabilities().Elicitation != nil
+}
+
+// confirmationResult is the result of a tool whose action wasn't approved.
+func confirmationResult(confirmation Confirmation, message string, locale Locale) *mcp.CallToolResult {
+	if confirmation == ConfirmationRequired {
+		return mcp.NewToolResultText(locale.T("confirm_required", message))
+	}
+
+	return mcp.NewToolResultText(locale.T("confirm_declined"))
+}
+
+// needsConfirmation reports whether the calls of tool have to be approved
+// by the user: the ones of CONFIRM_TOOLS and, unless CONFIRM_SIDE_EFFECTS is
+// off, the ones that change something in EDteam. The tools that only change
+// the server, like the watchlist, are annotated as closed world and don't
+// need it. The tools that confirm on their own, with a confirm argument,
+// are left alone.
+func needsConfirmation(tool mcp.Tool, cfg Config) bool {
+	if _, ok := tool.InputSchema.Properties["confirm"]; ok {
+		return false
+	}
+	if matchesTool(cfg.ConfirmTools, tool.Name) {
+		return true
+	}
+	if !cfg.ConfirmSideEffects {
+		return false
+	}
+	if hint := tool.Annotations.ReadOnlyHint; hint != nil && *hint {
+		return false
+	}
+	if hint := tool.Annotations.OpenWorldHint; hint != nil && !*hint {
+		return false
+	}
+
+	return true
+}
+
+// confirmSideEffects asks the user to approve the calls of the tools that
+// need confirmation before running them, describing every call with
+// describe. CONFIRM_SIDE_EFFECTS can be turned off for the clients that
+// can't ask the user, where asking in the chat is enough.
+func confirmSideEffects(cfg Config, s *server.MCPServer, describe func(ctx context.Context, tool mcp.Tool, args map[string]any, locale Locale) string) ToolMiddleware {
+	return func(tool mcp.Tool, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		if !needsConfirmation(tool, cfg) {
+			return next
+		}
+
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := NewArgs(request.GetArguments())
+			locale := args.Locale(cfg.Locale)
+			confirm := args.Bool("confirm", false)
+			if args.Err() != nil {
+				// The handler reports the invalid arguments.
+				return next(ctx, request)
+			}
+
+			message := describe(ctx, tool, request.GetArguments(), locale)
+			confirmation, err := confirmAction(ctx, s, message, confirm)
+			if err != nil {
+				return toolErrorResult(err, locale), nil
+			}
+			if confirmation != ConfirmationAccepted {
+				return confirmationResult(confirmation, message, locale), nil
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// withConfirmArgument declares the confirm argument read by
+// confirmSideEffects in the tools that need confirmation.
+func withConfirmArgument(tools []server.ServerTool, cfg Config) []server.ServerTool {
+	for i, tool := range tools {
+		if !needsConfirmation(tool.Tool, cfg) {
+			continue
+		}
+		properties := maps.Clone(tool.Tool.InputSchema.Properties)
+		if properties == nil {
+			properties = map[string]any{}
+		}
+		properties["confirm"] = map[string]any{
+			"type":        "boolean",
+			"description": "Set to true once the user agreed to the action, only used by clients that can't ask the user directly",
+			"default":     false,
+		}
+		tools[i].Tool.InputSchema.Properties = properties
+	}
+
+	return tools
+}
+
+// describeCall returns the question confirmSideEffects asks for a call. The
+// calls about a course name it with its price, the rest list the tool and
+// its arguments.
+func describeCall(catalog *Catalog, codes map[int]string) func(ctx context.Context, tool mcp.Tool, args map[string]any, locale Locale) string {
+	return func(ctx context.Context, tool mcp.Tool, args map[string]any, locale Locale) string {
+		parsed := NewArgs(args)
+		courseID := parsed.Int("course_id", 0, 1, MaxSafeInt)
+		if courseID > 0 && parsed.Err() == nil {
+			if name, price, ok := coursePrice(ctx, catalog, courseID, codes); ok {
+				switch tool.Name {
+				case "Shopping-Cart-Add-Course":
+					return locale.T("confirm_cart_add", name, price)
+				case "Gift-Course":
+					return locale.T("confirm_gift", name, price, parsed.String("recipient_email", ""))
+				}
+			}
+		}
+
+		shown := make(map[string]any, len(args))
+		for name, value := range args {
+			if name != "confirm" && name != "locale" {
+				shown[name] = value
+			}
+		}
+		raw, _ := json.Marshal(shown)
+
+		return locale.T("confirm_tool", tool.Name, raw)
+	}
+}
+
+// coursePrice returns the name and the price of a course of the catalog.
+func coursePrice(ctx context.Context, catalog *Catalog, courseID int, codes map[int]string) (string, string, bool) {
+	courses, err := catalog.Courses(ctx)
+	if err != nil {
+		return "", "", false
+	}
+	i := courseIndex(courses, courseID)
+	if i < 0 {
+		return "", "", false
+	}
+	item := courses.Data[i]
+	price := "-"
+	if len(item.CoursePrices) > 0 {
+		p := item.CoursePrices[0]
+		price = fmt.Sprintf("%d %s", p.Price, currencyName(codes, p.CurrencyId))
+	}
+
+	return item.Course.Name, price, true
+}
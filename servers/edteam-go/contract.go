@@ -0,0 +1,265 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"edteam-mcp/pkg/edteam"
+)
+
+// ContractReport collects the differences between the EDteam responses and
+// the models found by --check-contract: the fields EDteam added and the
+// fields of the models it no longer sends.
+type ContractReport struct {
+	mu      sync.Mutex
+	added   map[string]bool
+	removed map[string]bool
+}
+
+type contractReportKey struct{}
+
+func withContractReport(ctx context.Context) (context.Context, *ContractReport) {
+	report := &ContractReport{added: map[string]bool{}, removed: map[string]bool{}}
+	return context.WithValue(ctx, contractReportKey{}, report), report
+}
+
+// recordContract compares the raw JSON with the type of v when the context
+// carries a ContractReport. decodeJSON calls it for every response.
+func recordContract(ctx context.Context, body []byte, v any) {
+	report, ok := ctx.Value(contractReportKey{}).(*ContractReport)
+	if !ok {
+		return
+	}
+	var raw any
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return
+	}
+
+	t := reflect.TypeOf(v)
+	root := t.Elem().Name()
+	var added []string
+	unknownFields(raw, t, root, map[string]bool{}, &added)
+	removed := missingFields(raw, t, root)
+
+	report.mu.Lock()
+	defer report.mu.Unlock()
+	for _, field := range added {
+		report.added[field] = true
+	}
+	for _, field := range removed {
+		report.removed[field] = true
+	}
+}
+
+// Changes describes the differences, one per line. A field removed and
+// another added in the same object are reported as a rename.
+func (r *ContractReport) Changes() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	addedByParent := map[string][]string{}
+	removedByParent := map[string][]string{}
+	for field := range r.added {
+		parent := path.Dir(strings.ReplaceAll(field, ".", "/"))
+		addedByParent[parent] = append(addedByParent[parent], field)
+	}
+	for field := range r.removed {
+		parent := path.Dir(strings.ReplaceAll(field, ".", "/"))
+		removedByParent[parent] = append(removedByParent[parent], field)
+	}
+
+	var changes []string
+	for parent, removed := range removedByParent {
+		added := addedByParent[parent]
+		if len(removed) == 1 && len(added) == 1 {
+			changes = append(changes, fmt.Sprintf("renamed %s to %s", removed[0], added[0]))
+			delete(addedByParent, parent)
+			continue
+		}
+		for _, field := range removed {
+			changes = append(changes, "removed "+field)
+		}
+	}
+	for _, added := range addedByParent {
+		for _, field := range added {
+			changes = append(changes, "added "+field)
+		}
+	}
+	sort.Strings(changes)
+
+	return changes
+}
+
+// missingFields returns the fields of t that value doesn't have. Fields
+// tagged omitempty and pointers are optional. In arrays a field is missing
+// only when no element has it.
+func missingFields(value any, t reflect.Type, path string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok || timeTypes[t] {
+			return nil
+		}
+
+		present := make(map[string]any, len(object))
+		for key, child := range object {
+			present[strings.ToLower(key)] = child
+		}
+
+		var missing []string
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := jsonName(field)
+			if name == "-" || !field.IsExported() {
+				continue
+			}
+			child, ok := present[strings.ToLower(name)]
+			if !ok {
+				if !strings.Contains(field.Tag.Get("json"), ",omitempty") && field.Type.Kind() != reflect.Pointer {
+					missing = append(missing, path+"."+name)
+				}
+				continue
+			}
+			missing = append(missing, missingFields(child, field.Type, path+"."+name)...)
+		}
+
+		return missing
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok || len(items) == 0 {
+			return nil
+		}
+
+		counts := map[string]int{}
+		for _, item := range items {
+			for _, field := range missingFields(item, t.Elem(), path+"[]") {
+				counts[field]++
+			}
+		}
+		var missing []string
+		for field, count := range counts {
+			if count == len(items) {
+				missing = append(missing, field)
+			}
+		}
+		sort.Strings(missing)
+
+		return missing
+	}
+
+	return nil
+}
+
+// contractCheck calls one read-only endpoint, the responses are compared
+// with the models by decodeJSON.
+type contractCheck struct {
+	name string
+	call func(ctx context.Context, token string) error
+}
+
+// checkContract calls the read-only EDteam endpoints with the account of
+// cfg and writes the differences with the models to w. It returns the exit
+// code of --check-contract: 0 when every response matches the models.
+func checkContract(ctx context.Context, client *edteam.Client, cfg Config, w io.Writer) int {
+	session, err := NewSession(ctx, client, cfg.Email, cfg.Password)
+	if err != nil {
+		fmt.Fprintf(w, "login: %v\n", err)
+		return 1
+	}
+
+	var slug string
+	checks := []contractCheck{
+		{"courses", func(ctx context.Context, token string) error {
+			courses, err := client.Courses.List(ctx, 1, 10)
+			if err == nil && len(courses.Data) > 0 {
+				slug = courses.Data[0].Course.Slug
+			}
+			return err
+		}},
+		{"curriculum", func(ctx context.Context, token string) error {
+			_, err := client.Courses.Curriculum(ctx, slug)
+			return err
+		}},
+		{"faq", func(ctx context.Context, token string) error {
+			_, err := client.Courses.FAQ(ctx, slug)
+			return err
+		}},
+		{"trailer", func(ctx context.Context, token string) error {
+			_, err := client.Courses.Trailer(ctx, slug)
+			return err
+		}},
+		{"blog posts", func(ctx context.Context, token string) error {
+			_, err := client.Content.BlogPosts(ctx)
+			return err
+		}},
+		{"live events", func(ctx context.Context, token string) error {
+			_, err := client.Content.LiveEvents(ctx)
+			return err
+		}},
+		{"profile", func(ctx context.Context, token string) error {
+			_, err := client.Account.Profile(ctx, token)
+			return err
+		}},
+		{"subscriptions", func(ctx context.Context, token string) error {
+			_, err := client.Subscriptions.List(ctx, token)
+			return err
+		}},
+		{"last watched", func(ctx context.Context, token string) error {
+			_, _, err := client.Account.LastWatched(ctx, token)
+			return err
+		}},
+		{"owned courses", func(ctx context.Context, token string) error {
+			_, err := client.Account.OwnedCourses(ctx, token)
+			return err
+		}},
+		{"referral", func(ctx context.Context, token string) error {
+			_, err := client.Account.Referral(ctx, token)
+			return err
+		}},
+		{"billing address", func(ctx context.Context, token string) error {
+			_, err := client.Billing.Address(ctx, token)
+			return err
+		}},
+		{"payment methods", func(ctx context.Context, token string) error {
+			_, err := client.Billing.PaymentMethods(ctx, token)
+			return err
+		}},
+	}
+
+	code := 0
+	for _, check := range checks {
+		checkCtx, report := withContractReport(ctx)
+		err := session.Do(checkCtx, func(token string) error {
+			return check.call(checkCtx, token)
+		})
+		if err != nil {
+			fmt.Fprintf(w, "%s: failed: %v\n", check.name, err)
+			code = 1
+			continue
+		}
+
+		changes := report.Changes()
+		if len(changes) == 0 {
+			fmt.Fprintf(w, "%s: ok\n", check.name)
+			continue
+		}
+		code = 1
+		fmt.Fprintf(w, "%s:\n", check.name)
+		for _, change := range changes {
+			fmt.Fprintf(w, "  %s\n", change)
+		}
+	}
+
+	return code
+}
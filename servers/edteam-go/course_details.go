@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// maxCourseDetails bounds the courses of a Courses-Details call, every one
+// of them costs two calls to EDteam.
+const maxCourseDetails = 10
+
+// courseDetailsConcurrency bounds the courses fetched at the same time, so a
+// call makes at most twice as many requests to EDteam at once.
+const courseDetailsConcurrency = 4
+
+// courseDetails looks up the courses by id and slug, in that order, and
+// fetches their trailers and curriculums concurrently. The prices and the
+// professors come with the catalog. A course that can't
+// be found or fetched gets an error instead of failing the others.
+func courseDetails(ctx context.Context, api edteam.CoursesAPI, courses CourseResponse, ids []int, slugs []string, now time.Time, locale Locale) []CourseDetail {
+	detail := func(i int) CourseDetail {
+		course := newCourseView(courses.Data[i])
+		humanizeCourse(&course, now, locale)
+		return CourseDetail{Course: &course}
+	}
+
+	details := make([]CourseDetail, 0, len(ids)+len(slugs))
+	for _, id := range ids {
+		if i := courseIndex(courses, id); i >= 0 {
+			details = append(details, detail(i))
+		} else {
+			details = append(details, CourseDetail{Error: courseNotFound(locale.T("course_not_found", id), locale)})
+		}
+	}
+	for _, slug := range slugs {
+		if i := courseSlugIndex(courses, slug); i >= 0 {
+			details = append(details, detail(i))
+		} else {
+			details = append(details, CourseDetail{Error: courseNotFound(locale.T("course_slug_not_found", slug), locale)})
+		}
+	}
+
+	// The failures are kept in the details, so the group never cancels.
+	var g errgroup.Group
+	g.SetLimit(courseDetailsConcurrency)
+	for i := range details {
+		if details[i].Course == nil {
+			continue
+		}
+		detail := &details[i]
+		g.Go(func() error {
+			fetchCourseDetail(ctx, api, detail, locale)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return details
+}
+
+func fetchCourseDetail(ctx context.Context, api edteam.CoursesAPI, detail *CourseDetail, locale Locale) {
+	trailer, curriculum, err := getTrailerAndCurriculum(ctx, api, detail.Course.Course.Slug)
+	if err != nil {
+		detail.Error = failedDetail(err, locale)
+		return
+	}
+
+	classes, seconds := curriculum.Duration()
+	detail.Classes = classes
+	detail.DurationHours = hours(seconds)
+	detail.TrailerURL = trailer.Data.URL
+	detail.Curriculum = curriculum.Data
+}
+
+func courseNotFound(message string, locale Locale) *ToolError {
+	return &ToolError{
+		Category: CategoryNotFound,
+		Code:     CategoryNotFound,
+		Message:  message,
+		NextStep: locale.T("next_step_" + CategoryNotFound),
+	}
+}
+
+func failedDetail(err error, locale Locale) *ToolError {
+	toolError := classifyError(err, locale)
+	return &toolError
+}
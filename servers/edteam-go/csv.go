@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DatasetSubscriptions = "subscriptions"
+	// DatasetCourses is the full catalog with the duration of every course,
+	// it is exported in the background.
+	DatasetCourses = "courses"
+)
+
+func subscriptionsCSV(subscriptions SubscriptionsView) (string, error) {
+	return recordsCSV(subscriptionFields, subscriptionRecords(subscriptions, subscriptionFields))
+}
+
+func coursesCSV(courses CoursesView) (string, error) {
+	return recordsCSV(courseFields, courseRecords(courses, courseFields))
+}
+
+func recordsCSV(fields []string, records []Record) (string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	if err := w.Write(fields); err != nil {
+		return "", fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for _, record := range records {
+		row := make([]string, 0, len(fields))
+		for _, field := range fields {
+			row = append(row, csvValue(record[field]))
+		}
+		if err := w.Write(row); err != nil {
+			return "", fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	return b.String(), nil
+}
+
+func csvValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(v, ", ")
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case float64:
+		return fmt.Sprintf("%g", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
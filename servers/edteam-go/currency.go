@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"edteam-mcp/pkg/edteam"
+)
+
+// defaultCurrencyCodes maps the EDteam currency_id values to ISO 4217 codes.
+// It can be extended with the CURRENCY_CODES environment variable.
+var defaultCurrencyCodes = map[int]string{
+	1: "USD",
+}
+
+var currencyPattern = regexp.MustCompile(`^[A-Za-z]{3}$`)
+
+// Rates holds the exchange rates of every currency against Base (whose rate
+// is 1).
+type Rates struct {
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// parseCurrencyCodes parses values like "1=USD,2=PEN" on top of the defaults.
+func parseCurrencyCodes(value string) (map[int]string, error) {
+	codes := make(map[int]string, len(defaultCurrencyCodes))
+	for id, code := range defaultCurrencyCodes {
+		codes[id] = code
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		rawID, code, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid currency code %q, expected id=CODE", pair)
+		}
+		id, err := strconv.Atoi(strings.TrimSpace(rawID))
+		if err != nil {
+			return nil, fmt.Errorf("invalid currency id %q: %w", rawID, err)
+		}
+		codes[id] = strings.ToUpper(strings.TrimSpace(code))
+	}
+
+	return codes, nil
+}
+
+// LoadRates reads the exchange rates from a JSON file or an http(s) URL.
+func LoadRates(ctx context.Context, client *edteam.Client, source string) (Rates, error) {
+	var raw []byte
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		statusCode, responseBody, err := client.Send(ctx, true, http.MethodGet, source, "", nil)
+		if err != nil {
+			return Rates{}, err
+		}
+		if statusCode != http.StatusOK {
+			return Rates{}, fmt.Errorf("unexpected status code: %d", statusCode)
+		}
+		raw = responseBody
+	} else {
+		var err error
+		raw, err = os.ReadFile(source)
+		if err != nil {
+			return Rates{}, fmt.Errorf("failed to read rates file: %w", err)
+		}
+	}
+
+	var rates Rates
+	if err := json.Unmarshal(raw, &rates); err != nil {
+		return Rates{}, fmt.Errorf("failed to unmarshal rates: %w", err)
+	}
+	rates.Base = strings.ToUpper(rates.Base)
+	normalized := make(map[string]float64, len(rates.Rates)+1)
+	for code, rate := range rates.Rates {
+		normalized[strings.ToUpper(code)] = rate
+	}
+	normalized[rates.Base] = 1
+	rates.Rates = normalized
+
+	return rates, nil
+}
+
+// Convert converts amount from one currency into another.
+func (r Rates) Convert(amount float64, from, to string) (float64, error) {
+	if from == to {
+		return amount, nil
+	}
+	fromRate, ok := r.Rates[from]
+	if !ok || fromRate <= 0 {
+		return 0, fmt.Errorf("no exchange rate for %s", from)
+	}
+	toRate, ok := r.Rates[to]
+	if !ok || toRate <= 0 {
+		return 0, fmt.Errorf("no exchange rate for %s", to)
+	}
+
+	return math.Round(amount/fromRate*toRate*100) / 100, nil
+}
+
+// applyCurrencies resolves the ISO code of every price and, when target is
+// not empty, converts the prices into that currency.
+func applyCurrencies(courses *CoursesView, codes map[int]string, rates Rates, target string) error {
+	target = strings.ToUpper(strings.TrimSpace(target))
+
+	for i := range courses.Data {
+		for j := range courses.Data[i].CoursePrices {
+			price := &courses.Data[i].CoursePrices[j]
+			price.Currency = codes[price.CurrencyId]
+			if target == "" || price.Currency == "" {
+				continue
+			}
+
+			converted, err := rates.Convert(float64(price.Price), price.Currency, target)
+			if err != nil {
+				return fmt.Errorf("failed to convert prices to %s: %w", target, err)
+			}
+			convertedBase, err := rates.Convert(float64(price.BasePrice), price.Currency, target)
+			if err != nil {
+				return fmt.Errorf("failed to convert prices to %s: %w", target, err)
+			}
+			price.ConvertedPrice = &converted
+			price.ConvertedBasePrice = &convertedBase
+			price.ConvertedCurrency = target
+		}
+	}
+
+	return nil
+}
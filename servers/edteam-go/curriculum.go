@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+)
+
+type courseDuration struct {
+	classes   int
+	seconds   int
+	fetchedAt time.Time
+}
+
+// Durations caches the number of classes and the duration of the courses,
+// which change far less often than the catalog.
+type Durations struct {
+	api edteam.CoursesAPI
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]courseDuration
+}
+
+func NewDurations(api edteam.CoursesAPI, ttl time.Duration) *Durations {
+	return &Durations{api: api, ttl: ttl, entries: make(map[string]courseDuration)}
+}
+
+// Get returns the number of classes and the duration in seconds of the course.
+func (d *Durations) Get(ctx context.Context, slug string) (int, int, error) {
+	d.mu.Lock()
+	entry, ok := d.entries[slug]
+	d.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < d.ttl {
+		return entry.classes, entry.seconds, nil
+	}
+
+	curriculum, err := d.api.Curriculum(ctx, slug)
+	if err != nil {
+		return 0, 0, err
+	}
+	classes, seconds := curriculum.Duration()
+
+	d.mu.Lock()
+	d.entries[slug] = courseDuration{classes: classes, seconds: seconds, fetchedAt: time.Now()}
+	d.mu.Unlock()
+
+	return classes, seconds, nil
+}
+
+// enrichDurations adds the number of classes and the duration to the courses.
+// A course whose curriculum can't be fetched is left without them. progress,
+// when not nil, is called after every course.
+func enrichDurations(ctx context.Context, courses *CoursesView, durations *Durations, progress func(done, total int)) {
+	for i := range courses.Data {
+		course := &courses.Data[i].Course
+		classes, seconds, err := durations.Get(ctx, course.Slug)
+		if err != nil {
+			log.Printf("failed to get the duration of %s: %v", course.Slug, err)
+		} else {
+			durationHours := hours(seconds)
+			course.Classes = &classes
+			course.DurationHours = &durationHours
+		}
+		if progress != nil {
+			progress(i+1, len(courses.Data))
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// loggedDrift keeps the unknown fields already logged, so every change in the
+// EDteam API is logged once instead of on every request.
+var loggedDrift sync.Map
+
+// DriftReport collects the unknown fields found while decoding the responses
+// of a single tool call.
+type DriftReport struct {
+	mu     sync.Mutex
+	fields map[string]bool
+}
+
+func (r *DriftReport) add(fields []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.fields == nil {
+		r.fields = make(map[string]bool)
+	}
+	for _, field := range fields {
+		r.fields[field] = true
+	}
+}
+
+func (r *DriftReport) Fields() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fields := make([]string, 0, len(r.fields))
+	for field := range r.fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	return fields
+}
+
+type driftReportKey struct{}
+
+func withDriftReport(ctx context.Context) (context.Context, *DriftReport) {
+	report := &DriftReport{}
+	return context.WithValue(ctx, driftReportKey{}, report), report
+}
+
+// detectDrift compares the raw JSON with the type of v, logs the fields that
+// the models don't know and adds them to the report of the context.
+func detectDrift(ctx context.Context, body []byte, v any) {
+	var raw any
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return
+	}
+
+	t := reflect.TypeOf(v)
+	seen := make(map[string]bool)
+	var fields []string
+	unknownFields(raw, t, t.Elem().Name(), seen, &fields)
+	if len(fields) == 0 {
+		return
+	}
+
+	for _, field := range fields {
+		if _, logged := loggedDrift.LoadOrStore(field, true); !logged {
+			log.Printf("schema drift detected: unknown field %s", field)
+		}
+	}
+	if report, ok := ctx.Value(driftReportKey{}).(*DriftReport); ok {
+		report.add(fields)
+	}
+}
+
+// timeTypes are decoded from a string, their fields aren't in the JSON.
+var timeTypes = map[reflect.Type]bool{
+	reflect.TypeOf(time.Time{}):   true,
+	reflect.TypeOf(edteam.Time{}): true,
+}
+
+func unknownFields(value any, t reflect.Type, path string, seen map[string]bool, fields *[]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok || timeTypes[t] {
+			return
+		}
+
+		known := make(map[string]reflect.StructField, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := jsonName(field)
+			if name != "-" && field.IsExported() {
+				known[strings.ToLower(name)] = field
+			}
+		}
+
+		for key, child := range object {
+			field, ok := known[strings.ToLower(key)]
+			if !ok {
+				if unknown := path + "." + key; !seen[unknown] {
+					seen[unknown] = true
+					*fields = append(*fields, unknown)
+				}
+				continue
+			}
+			unknownFields(child, field.Type, path+"."+key, seen, fields)
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok {
+			return
+		}
+		for _, item := range items {
+			unknownFields(item, t.Elem(), path+"[]", seen, fields)
+		}
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+// withDriftWarnings adds a warning to the result of the tool when the EDteam
+// responses had fields unknown to the models.
+func withDriftWarnings(enabled bool, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, report := withDriftReport(ctx)
+		result, err := handler(ctx, request)
+		if !enabled || err != nil || result == nil {
+			return result, err
+		}
+
+		if fields := report.Fields(); len(fields) > 0 {
+			result.Content = append(result.Content, mcp.NewTextContent("schema drift detected, the EDteam API returned unknown fields: "+strings.Join(fields, ", ")))
+		}
+
+		return result, nil
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"edteam-mcp/testsupport"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TestStdioBinary builds the server, spawns it against the fake EDteam and
+// talks MCP to it over stdio like a client would.
+func TestStdioBinary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and spawns the binary")
+	}
+
+	binary := filepath.Join(t.TempDir(), "edteam-mcp")
+	if output, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput(); err != nil {
+		t.Fatalf("go build failed: %v\n%s", err, output)
+	}
+
+	fake := testsupport.NewFakeEDteam(t)
+	env := []string{
+		"EDTEAM_BASE_URL=" + fake.URL,
+		"EMAIL=student@example.com",
+		"PASSWORD=secret",
+		"DATA_DIR=" + t.TempDir(),
+		"LOG_LEVEL=error",
+	}
+	c, err := client.NewStdioMCPClient(binary, env, "--transport", "stdio")
+	if err != nil {
+		t.Fatalf("failed to spawn the server: %v", err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	initialize := mcp.InitializeRequest{}
+	initialize.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initialize.Params.ClientInfo = mcp.Implementation{Name: "e2e", Version: "test"}
+	info, err := c.Initialize(ctx, initialize)
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if info.ServerInfo.Name != "EDteam API" || info.Capabilities.Tools == nil {
+		t.Errorf("Initialize() = %+v, want the EDteam server with tools", info.ServerInfo)
+	}
+
+	tools, err := c.ListTools(ctx, mcp.ListToolsRequest{})
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	var names []string
+	for _, tool := range tools.Tools {
+		names = append(names, tool.Name)
+	}
+
+	// A public tool, a tool of the account and one with a side effect.
+	calls := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"Courses-List", map[string]any{"limit": 1}, "go-avanzado"},
+		{"Subscriptions", nil, "active"},
+		{"Shopping-Cart-Add-Course", map[string]any{"course_id": 101, "confirm": true}, "shopping cart"},
+	}
+	for _, call := range calls {
+		if !slices.Contains(names, call.name) {
+			t.Errorf("ListTools() has no %s, got %v", call.name, names)
+			continue
+		}
+
+		req := mcp.CallToolRequest{}
+		req.Params.Name = call.name
+		req.Params.Arguments = call.args
+		result, err := c.CallTool(ctx, req)
+		if err != nil {
+			t.Errorf("CallTool(%s) error = %v", call.name, err)
+			continue
+		}
+		text := mcp.GetTextFromContent(result.Content[0])
+		if result.IsError || !strings.Contains(text, call.want) {
+			t.Errorf("CallTool(%s) = %s, want a result with %q", call.name, text, call.want)
+		}
+	}
+
+	var sentToEDteam []string
+	for _, request := range fake.Requests() {
+		sentToEDteam = append(sentToEDteam, request.Method+" "+request.Path)
+	}
+	if !slices.Contains(sentToEDteam, "POST /v2/private/shopping-carts") {
+		t.Errorf("the fake EDteam got %v, want the course added to the cart", sentToEDteam)
+	}
+}
@@ -0,0 +1,13 @@
+package main
+
+import "edteam-mcp/pkg/edteam"
+
+// The errors of the requests to EDteam, see the edteam package.
+type (
+	StatusError      = edteam.StatusError
+	NonJSONError     = edteam.NonJSONError
+	AmbiguousError   = edteam.AmbiguousError
+	UnavailableError = edteam.UnavailableError
+
+	ResponseTooLargeError = edteam.ResponseTooLargeError
+)
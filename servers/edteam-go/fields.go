@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Record is a flat view of a course or a subscription used to project the
+// fields requested by the caller.
+type Record map[string]any
+
+var courseFields = []string{
+	"id", "name", "slug", "subtitle", "level", "course_type", "addressed_to", "you_learn",
+	"on_sale", "visible", "picture", "vertical_picture", "created_at", "created_at_human",
+	"price", "base_price", "currency", "converted_price", "converted_base_price", "converted_currency",
+	"professors", "classes", "duration_hours",
+}
+
+// durationFields need the curriculum of every course.
+var durationFields = []string{"classes", "duration_hours"}
+
+const (
+	VerbosityCompact = "compact"
+	VerbosityFull    = "full"
+)
+
+// compactCourseFields are returned by default. Besides name, slug, level,
+// price and professors it keeps the id, needed to add the course to the
+// cart, and the currency that gives meaning to the price.
+var compactCourseFields = []string{"id", "name", "slug", "level", "price", "currency", "professors"}
+
+// compactFields returns the compact fields of a listing. When the prices
+// are converted into currency it adds the converted price, otherwise the
+// conversion would be left out.
+func compactFields(currency string) []string {
+	if currency == "" {
+		return compactCourseFields
+	}
+
+	return append(slices.Clip(compactCourseFields), "converted_price", "converted_currency")
+}
+
+var subscriptionFields = []string{
+	"id", "subscription_date", "months", "begins_at", "begins_at_human", "ends_at", "ends_at_human",
+	"state", "observations", "created_at", "buyer",
+}
+
+// courseRecords flattens the courses keeping only fields. A field without a
+// value, like the price of a course without prices, is left out.
+func courseRecords(courses CoursesView, fields []string) []Record {
+	records := make([]Record, 0, len(courses.Data))
+	for i := range courses.Data {
+		record := make(Record, len(fields))
+		for _, field := range fields {
+			if value, ok := courseField(&courses.Data[i], field); ok {
+				record[field] = value
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func courseField(item *CourseView, field string) (any, bool) {
+	course := &item.Course
+	switch field {
+	case "id":
+		return course.ID, true
+	case "name":
+		return course.Name, true
+	case "slug":
+		return course.Slug, true
+	case "subtitle":
+		return course.Subtitle, true
+	case "level":
+		return course.Level, true
+	case "course_type":
+		return course.CourseType, true
+	case "addressed_to":
+		return course.AddressedTo, true
+	case "you_learn":
+		return course.YouLearn, true
+	case "on_sale":
+		return course.OnSale, true
+	case "visible":
+		return course.Visible, true
+	case "picture":
+		return course.Picture, true
+	case "vertical_picture":
+		return course.VerticalPicture, true
+	case "created_at":
+		return course.CreatedAt.Time, true
+	case "created_at_human":
+		return course.CreatedAtHuman, course.CreatedAtHuman != ""
+	case "professors":
+		professors := make([]string, 0, len(item.Professors))
+		for _, professor := range item.Professors {
+			professors = append(professors, strings.TrimSpace(professor.Firstname+" "+professor.Lastname))
+		}
+		return professors, true
+	case "classes":
+		return derefAny(course.Classes)
+	case "duration_hours":
+		return derefAny(course.DurationHours)
+	}
+
+	if len(item.CoursePrices) == 0 {
+		return nil, false
+	}
+	price := &item.CoursePrices[0]
+	switch field {
+	case "price":
+		return price.Price, true
+	case "base_price":
+		return price.BasePrice, true
+	case "currency":
+		return price.Currency, true
+	case "converted_price":
+		return derefAny(price.ConvertedPrice)
+	case "converted_base_price":
+		return derefAny(price.ConvertedBasePrice)
+	case "converted_currency":
+		return price.ConvertedCurrency, price.ConvertedPrice != nil
+	}
+
+	return nil, false
+}
+
+// subscriptionRecords flattens the subscriptions keeping only fields, with
+// the values of their JSON: the times are RFC 3339 strings and the empty
+// optional fields are left out.
+func subscriptionRecords(subscriptions SubscriptionsView, fields []string) []Record {
+	records := make([]Record, 0, len(subscriptions.Data))
+	for i := range subscriptions.Data {
+		record := make(Record, len(fields))
+		for _, field := range fields {
+			if value, ok := subscriptionField(&subscriptions.Data[i], field); ok {
+				record[field] = value
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func subscriptionField(subscription *SubscriptionView, field string) (any, bool) {
+	switch field {
+	case "id":
+		return subscription.ID, true
+	case "subscription_date":
+		return subscription.SubscriptionDate.Format(time.RFC3339Nano), true
+	case "months":
+		return subscription.Months, true
+	case "begins_at":
+		return subscription.BeginsAt.Format(time.RFC3339Nano), true
+	case "begins_at_human":
+		return subscription.BeginsAtHuman, subscription.BeginsAtHuman != ""
+	case "ends_at":
+		return subscription.EndsAt.Format(time.RFC3339Nano), true
+	case "ends_at_human":
+		return subscription.EndsAtHuman, subscription.EndsAtHuman != ""
+	case "state":
+		return subscription.State, true
+	case "observations":
+		return derefAny(subscription.Observations)
+	case "created_at":
+		return subscription.CreatedAt.Format(time.RFC3339Nano), true
+	case "buyer":
+		return subscription.Buyer, true
+	}
+
+	return nil, false
+}
+
+// derefAny returns the value p points to, false when it is nil.
+func derefAny[T any](p *T) (any, bool) {
+	if p == nil {
+		return nil, false
+	}
+
+	return *p, true
+}
+
+func containsAny(values []string, wanted []string) bool {
+	for _, value := range wanted {
+		if contains(values, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func recordsMarkdown(records []Record, fields []string, locale Locale) string {
+	headers := make([]string, 0, len(fields))
+	for _, field := range fields {
+		headers = append(headers, locale.T(field))
+	}
+
+	rows := make([][]string, 0, len(records))
+	for _, record := range records {
+		row := make([]string, 0, len(fields))
+		for _, field := range fields {
+			row = append(row, markdownValue(record[field]))
+		}
+		rows = append(rows, row)
+	}
+
+	return markdownTable(headers, rows)
+}
+
+func markdownValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "-"
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, markdownValue(item))
+		}
+		return strings.Join(values, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func fieldsOption(available []string) mcp.ToolOption {
+	return mcp.WithArray(
+		"fields",
+		mcp.Description("Return only these fields of every item"),
+		mcp.Items(map[string]any{"type": "string", "enum": available}),
+	)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	TransportStdio = "stdio"
+	TransportSSE   = "sse"
+	TransportHTTP  = "http"
+)
+
+var transports = []string{TransportStdio, TransportSSE, TransportHTTP}
+
+var logLevels = map[string]slog.Level{
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+}
+
+// Flags are the command line options. They take precedence over the
+// environment variables and the config file.
+type Flags struct {
+	Transport  string
+	Listen     string
+	LogLevel   string
+	ConfigFile string
+	ReadOnly   bool
+	Daemon     bool
+	PIDFile    string
+
+	// CheckContract compares the real EDteam responses with the models and
+	// exits instead of serving.
+	CheckContract bool
+	// DebugPprof is the localhost address of the pprof profiles, empty
+	// doesn't serve them.
+	DebugPprof string
+
+	// set holds the flags given in the command line.
+	set map[string]bool
+}
+
+func parseFlags(args []string, output io.Writer) (Flags, error) {
+	var flags Flags
+	fs := flag.NewFlagSet("edteam-mcp", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&flags.Transport, "transport", TransportStdio, "transport to serve MCP over: "+strings.Join(transports, ", "))
+	fs.StringVar(&flags.Listen, "listen", "127.0.0.1:8080", "address to listen on with the sse and http transports")
+	fs.StringVar(&flags.LogLevel, "log-level", "info", "minimum level of the logs written to stderr: debug, info, warn, error")
+	fs.BoolVar(&flags.ReadOnly, "read-only", false, "expose only the tools without side effects, nothing can be bought, posted or changed")
+	fs.BoolVar(&flags.Daemon, "daemon", false, "run under a service manager: persist the token and caches across restarts, write a PID file and reload --config on SIGHUP")
+	fs.StringVar(&flags.PIDFile, "pid-file", "", "file to write the process ID to, defaults to DATA_DIR/edteam-mcp.pid with --daemon")
+	fs.StringVar(&flags.ConfigFile, "config", "", "file with KEY=VALUE lines to read the environment variables from")
+	fs.BoolVar(&flags.CheckContract, "check-contract", false, "call the read-only EDteam endpoints with EMAIL and PASSWORD, report the fields added, removed or renamed against the models and exit")
+	fs.StringVar(&flags.DebugPprof, "debug-pprof", "", "localhost address to serve the net/http/pprof profiles on, like localhost:6060, to investigate the memory and CPU of a running server")
+	if err := fs.Parse(args); err != nil {
+		return Flags{}, err
+	}
+	if fs.NArg() > 0 {
+		return Flags{}, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	flags.set = map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		flags.set[f.Name] = true
+	})
+
+	return flags, nil
+}
+
+// apply overrides the config with the flags given in the command line.
+func (f Flags) apply(cfg *Config) error {
+	if f.set["transport"] {
+		cfg.Transport = f.Transport
+	}
+	if f.set["listen"] {
+		cfg.Listen = f.Listen
+	}
+	if f.set["log-level"] {
+		cfg.LogLevel = f.LogLevel
+	}
+	if f.set["read-only"] {
+		cfg.ReadOnly = f.ReadOnly
+	}
+	if f.set["daemon"] {
+		cfg.Daemon = f.Daemon
+		if cfg.Daemon && cfg.PIDFile == "" {
+			cfg.PIDFile = filepath.Join(cfg.DataDir, "edteam-mcp.pid")
+		}
+	}
+	if f.set["pid-file"] {
+		cfg.PIDFile = f.PIDFile
+	}
+	var problems ConfigError
+	if !contains(transports, cfg.Transport) {
+		problems.add(fmt.Errorf("unsupported transport %q, use one of: %s", cfg.Transport, strings.Join(transports, ", ")))
+	}
+	if cfg.APIKey != "" && cfg.Transport == TransportStdio {
+		slog.Warn("API_KEY is only checked by the sse and http transports")
+	}
+	if cfg.MultiTenant && cfg.Transport == TransportStdio {
+		problems.add(fmt.Errorf("MULTI_TENANT needs the %s or %s transport", TransportSSE, TransportHTTP))
+	}
+	if f.DebugPprof != "" {
+		problems.add(checkLoopback(f.DebugPprof))
+	}
+	if _, ok := logLevels[cfg.LogLevel]; !ok {
+		problems.add(fmt.Errorf("unsupported log level %q, use one of: debug, info, warn, error", cfg.LogLevel))
+	}
+
+	return problems.err()
+}
+
+// loadEnvFile sets the environment variables of a KEY=VALUE file and
+// returns the ones it set. Blank lines and lines starting with # are
+// skipped, variables already set in the environment are kept.
+func loadEnvFile(path string) ([]string, error) {
+	values, err := parseEnvFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var set []string
+	for key, value := range values {
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return set, err
+		}
+		set = append(set, key)
+	}
+
+	return set, nil
+}
+
+func parseEnvFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, n)
+		}
+		values[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+
+	return values, scanner.Err()
+}
+
+// setupLogging writes the logs to stderr, stdout is reserved for the stdio
+// transport. The log package is routed through the same handler at the info
+// level. The level returned can change when the config file is reloaded.
+func setupLogging(level string) *slog.LevelVar {
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(logLevels[level])
+	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel})
+	slog.SetDefault(slog.New(handler))
+
+	return logLevel
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	FormatJSON      = "json"
+	FormatMarkdown  = "markdown"
+	FormatJSONLines = "jsonl"
+)
+
+var formats = []string{FormatJSON, FormatMarkdown, FormatJSONLines}
+
+// render renders data in the requested format. items is the slice of data
+// rendered one JSON object per line in the jsonl format.
+func render(format string, data any, items any, markdown func() string) (string, error) {
+	switch format {
+	case FormatMarkdown:
+		return markdown(), nil
+	case FormatJSONLines:
+		return jsonLines(items)
+	}
+
+	return marshalString(data)
+}
+
+// maxPooledResult keeps the buffers grown by a large result, like the whole
+// catalog, out of the pool.
+const maxPooledResult = 256 << 10
+
+var resultBuffers = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// marshalString returns v encoded like json.Marshal does. The JSON is built
+// in a pooled buffer and copied once, into the string, where json.Marshal
+// copies it into a slice that the conversion to string copies again.
+func marshalString(v any) (string, error) {
+	buf := resultBuffers.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledResult {
+			buf.Reset()
+			resultBuffers.Put(buf)
+		}
+	}()
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		return "", err
+	}
+
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
+
+// jsonResult returns v as a JSON text result.
+func jsonResult(v any) (*mcp.CallToolResult, error) {
+	text, err := marshalString(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(text), nil
+}
+
+// jsonLines encodes every item of items on its own line. The encoder ends
+// every value with a newline, right into the builder.
+func jsonLines(items any) (string, error) {
+	var b strings.Builder
+	encoder := json.NewEncoder(&b)
+	values := reflect.ValueOf(items)
+	for i := 0; i < values.Len(); i++ {
+		if err := encoder.Encode(values.Index(i).Interface()); err != nil {
+			return "", err
+		}
+	}
+
+	return b.String(), nil
+}
+
+func coursesMarkdown(courses CoursesView, locale Locale) string {
+	rows := make([][]string, 0, len(courses.Data))
+	for _, item := range courses.Data {
+		price := "-"
+		if len(item.CoursePrices) > 0 {
+			p := item.CoursePrices[0]
+			price = strings.TrimSpace(fmt.Sprintf("%d %s", p.Price, p.Currency))
+			if p.ConvertedPrice != nil {
+				price = fmt.Sprintf("%.2f %s", *p.ConvertedPrice, p.ConvertedCurrency)
+			}
+		}
+
+		professors := make([]string, 0, len(item.Professors))
+		for _, professor := range item.Professors {
+			professors = append(professors, strings.TrimSpace(professor.Firstname+" "+professor.Lastname))
+		}
+
+		duration := "-"
+		if item.Course.DurationHours != nil {
+			duration = locale.T("duration_value", *item.Course.Classes, *item.Course.DurationHours)
+		}
+
+		rows = append(rows, []string{
+			item.Course.Name,
+			item.Course.Level,
+			price,
+			strings.Join(professors, ", "),
+			duration,
+		})
+	}
+
+	return markdownTable([]string{locale.T("name"), locale.T("level"), locale.T("price"), locale.T("professor"), locale.T("duration")}, rows)
+}
+
+func subscriptionsMarkdown(subscriptions SubscriptionsView, locale Locale) string {
+	rows := make([][]string, 0, len(subscriptions.Data))
+	for _, subscription := range subscriptions.Data {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", subscription.ID),
+			subscription.State,
+			fmt.Sprintf("%d", subscription.Months),
+			subscription.BeginsAt.Format("2006-01-02"),
+			strings.TrimSpace(subscription.EndsAt.Format("2006-01-02") + " " + parenthesize(subscription.EndsAtHuman)),
+		})
+	}
+
+	return markdownTable([]string{locale.T("id"), locale.T("state"), locale.T("months"), locale.T("begins"), locale.T("ends")}, rows)
+}
+
+func markdownTable(headers []string, rows [][]string) string {
+	var b strings.Builder
+	writeMarkdownRow(&b, headers)
+
+	separators := make([]string, len(headers))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	writeMarkdownRow(&b, separators)
+
+	for _, row := range rows {
+		writeMarkdownRow(&b, row)
+	}
+
+	return b.String()
+}
+
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", `\|`)
+		cell = strings.ReplaceAll(cell, "\n", " ")
+		b.WriteString(" " + cell + " |")
+	}
+	b.WriteString("\n")
+}
+
+func parenthesize(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return "(" + value + ")"
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"edteam-mcp/testsupport"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// largeCatalog returns a catalog of n courses made from the fixture, like a
+// page of the largest size.
+func largeCatalog(tb testing.TB, n int) CoursesView {
+	tb.Helper()
+
+	var fixture CourseResponse
+	if err := json.Unmarshal(testsupport.Fixture(tb, "courses"), &fixture); err != nil {
+		tb.Fatal(err)
+	}
+	courses := CourseResponse{Data: make([]Course, n)}
+	for i := range courses.Data {
+		course := fixture.Data[i%len(fixture.Data)]
+		course.Course.ID = i + 1
+		course.Course.Slug = fmt.Sprintf("%s-%d", course.Course.Slug, i+1)
+		courses.Data[i] = course
+	}
+
+	return newCoursesView(courses)
+}
+
+func BenchmarkJSONResult(b *testing.B) {
+	courses := largeCatalog(b, 500)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := jsonResult(courses); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// largeSubscriptions returns a history of n subscriptions made from the
+// fixture.
+func largeSubscriptions(tb testing.TB, n int) SubscriptionsView {
+	tb.Helper()
+
+	var fixture SubscriptionResponse
+	if err := json.Unmarshal(testsupport.Fixture(tb, "subscriptions"), &fixture); err != nil {
+		tb.Fatal(err)
+	}
+	subscriptions := SubscriptionResponse{Data: make([]Subscription, n)}
+	for i := range subscriptions.Data {
+		subscriptions.Data[i] = fixture.Data[i%len(fixture.Data)]
+		subscriptions.Data[i].ID = i + 1
+	}
+
+	return newSubscriptionsView(subscriptions)
+}
+
+// BenchmarkCoursesPage renders a page of Courses-List with the compact
+// fields, the path of most calls.
+func BenchmarkCoursesPage(b *testing.B) {
+	courses := largeCatalog(b, 50)
+	p := Pagination{Page: 1, Limit: 50, HasMore: true, NextCursor: encodeCursor(2, 50)}
+	b.ReportAllocs()
+	for b.Loop() {
+		records := courseRecords(courses, compactCourseFields)
+		text, err := renderPage(FormatJSON, records, p, nil, LocaleEN)
+		if err != nil {
+			b.Fatal(err)
+		}
+		mcp.NewToolResultText(text)
+	}
+}
+
+func BenchmarkCoursesJSONLines(b *testing.B) {
+	courses := largeCatalog(b, 50)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := jsonLines(courses.Data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSubscriptionFields(b *testing.B) {
+	subscriptions := largeSubscriptions(b, 20)
+	fields := []string{"id", "state", "ends_at"}
+	b.ReportAllocs()
+	for b.Loop() {
+		records := subscriptionRecords(subscriptions, fields)
+		if _, err := render(FormatJSON, map[string]any{"data": records}, records, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestCompactFieldsKeepTheConversion(t *testing.T) {
+	courses := largeCatalog(t, 1)
+	converted := 12.5
+	courses.Data[0].CoursePrices[0].ConvertedPrice = &converted
+	courses.Data[0].CoursePrices[0].ConvertedCurrency = "USD"
+
+	fields := compactFields("USD")
+	text := recordsMarkdown(courseRecords(courses, fields), fields, LocaleES)
+	for _, want := range []string{"Profesores", "Precio convertido", "Moneda convertida", "12.5", "USD"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("the compact markdown has no %q:\n%s", want, text)
+		}
+	}
+	if got := compactFields(""); contains(got, "converted_price") {
+		t.Errorf("compactFields(\"\") = %v, want no converted price", got)
+	}
+}
+
+// TestAllocations holds the paths made cheaper by building only the
+// requested fields and encoding into pooled buffers to an allocation budget,
+// so a change that brings the intermediate copies back fails. The budgets
+// leave about 10% over the allocations measured with the benchmarks above,
+// next to the ones before the change:
+//
+//	BenchmarkCoursesPage         1767 -> 1057 allocs
+//	BenchmarkSubscriptionFields   842 ->  291 allocs
+//	BenchmarkCoursesJSONLines     313 ->  264 allocs
+//
+// The courses page went up to 1357 allocations when the professors joined
+// the compact fields.
+func TestAllocations(t *testing.T) {
+	if testing.CoverMode() != "" || raceEnabled {
+		t.Skip("coverage and the race detector add allocations")
+	}
+
+	courses := largeCatalog(t, 50)
+	subscriptions := largeSubscriptions(t, 20)
+	p := Pagination{Page: 1, Limit: 50, HasMore: true, NextCursor: encodeCursor(2, 50)}
+	tests := []struct {
+		name   string
+		budget float64
+		run    func() error
+	}{
+		{name: "courses page", budget: 1500, run: func() error {
+			_, err := renderPage(FormatJSON, courseRecords(courses, compactCourseFields), p, nil, LocaleEN)
+			return err
+		}},
+		{name: "subscription fields", budget: 320, run: func() error {
+			records := subscriptionRecords(subscriptions, []string{"id", "state", "ends_at"})
+			_, err := render(FormatJSON, map[string]any{"data": records}, records, nil)
+			return err
+		}},
+		{name: "courses jsonl", budget: 290, run: func() error {
+			_, err := jsonLines(courses.Data)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			allocs := testing.AllocsPerRun(50, func() {
+				if runErr := tt.run(); runErr != nil {
+					err = runErr
+				}
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if allocs > tt.budget {
+				t.Errorf("%.0f allocations per run, the budget is %.0f", allocs, tt.budget)
+			}
+		})
+	}
+}
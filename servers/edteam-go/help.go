@@ -0,0 +1,124 @@
+package main
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Help describes the tools of the server and how they are combined, for
+// the models that don't figure it out from the descriptions alone.
+type Help struct {
+	Tools     []ToolHelp `json:"tools"`
+	Workflows []Workflow `json:"workflows"`
+}
+
+type ToolHelp struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	ReadOnly    bool           `json:"read_only"`
+	Arguments   []ArgumentHelp `json:"arguments"`
+	Example     map[string]any `json:"example,omitempty"`
+}
+
+type ArgumentHelp struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+}
+
+// Workflow is a task and the tools that do it, in the order they are
+// called.
+type Workflow struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Steps       []string `json:"steps"`
+}
+
+// toolExamples are the arguments of a typical call of the tools that take
+// more than an id.
+var toolExamples = map[string]map[string]any{
+	"Courses-List":             {"limit": 5, "sort": SortPriceAsc, "verbosity": VerbosityCompact},
+	"Courses-Details":          {"slugs": []string{"go-avanzado", "introduccion-a-la-programacion"}},
+	"Course-Access":            {"course_id": 101},
+	"Shopping-Cart-Add-Course": {"course_id": 101},
+	"Gift-Course":              {"course_id": 101, "recipient_email": "ana@example.com"},
+	"Generate-Study-Plan":      {"goal": "backend development with Go", "weekly_hours": 5, "deadline": "2026-12-31"},
+	"Price-History":            {"course_id": 101, "currency": "USD"},
+	"Watch-Course":             {"action": WatchAdd, "course_id": 101},
+	"Export-CSV":               {"dataset": DatasetCourses},
+	"Job-Status":               {"job_id": "<job_id returned by Export-CSV>"},
+	"Community-Threads":        {"course_id": 101, "page": 1},
+	"Whats-New":                {"since": "2026-01-01"},
+}
+
+// workflows are the common tasks, the name of each is the key of its
+// description in the messages.
+var workflows = []Workflow{
+	{Name: "buy_course", Steps: []string{"Courses-List", "Courses-Details", "Course-Access", "Shopping-Cart-Add-Course"}},
+	{Name: "compare_courses", Steps: []string{"Courses-List", "Courses-Details", "Price-History"}},
+	{Name: "wait_for_sale", Steps: []string{"Price-History", "Watch-Course", "Whats-New"}},
+	{Name: "keep_learning", Steps: []string{"Continue-Learning", "Generate-Study-Plan", "Calendar-ICS"}},
+	{Name: "export_catalog", Steps: []string{"Export-CSV", "Job-Status", "Job-Result"}},
+	{Name: "manage_team", Steps: []string{"Team-Members", "Team-Member-Progress", "Team-Seat-Assign"}},
+	{Name: "debug_account", Steps: []string{"Whoami", "Session-Info", "Subscriptions"}},
+}
+
+// help describes the tools exposed, named tool when it isn't empty, and the
+// workflows whose every step is exposed.
+func help(tools map[string]*server.ServerTool, tool string, locale Locale) Help {
+	result := Help{Tools: []ToolHelp{}, Workflows: []Workflow{}}
+	for name, serverTool := range tools {
+		if tool != "" && name != tool {
+			continue
+		}
+		definition := serverTool.Tool
+		toolHelp := ToolHelp{
+			Name:        name,
+			Description: definition.Description,
+			ReadOnly:    definition.Annotations.ReadOnlyHint != nil && *definition.Annotations.ReadOnlyHint,
+			Arguments:   []ArgumentHelp{},
+			Example:     toolExamples[name],
+		}
+		for argument, schema := range definition.InputSchema.Properties {
+			property, _ := schema.(map[string]any)
+			argumentType, _ := property["type"].(string)
+			description, _ := property["description"].(string)
+			toolHelp.Arguments = append(toolHelp.Arguments, ArgumentHelp{
+				Name:        argument,
+				Type:        argumentType,
+				Description: description,
+				Required:    slices.Contains(definition.InputSchema.Required, argument),
+			})
+		}
+		// The required arguments first.
+		sort.Slice(toolHelp.Arguments, func(i, j int) bool {
+			a, b := toolHelp.Arguments[i], toolHelp.Arguments[j]
+			if a.Required != b.Required {
+				return a.Required
+			}
+			return a.Name < b.Name
+		})
+		result.Tools = append(result.Tools, toolHelp)
+	}
+	sort.Slice(result.Tools, func(i, j int) bool { return result.Tools[i].Name < result.Tools[j].Name })
+
+	for _, workflow := range workflows {
+		exposed := true
+		for _, step := range workflow.Steps {
+			if _, ok := tools[step]; !ok {
+				exposed = false
+				break
+			}
+		}
+		if !exposed || tool != "" && !slices.Contains(workflow.Steps, tool) {
+			continue
+		}
+		workflow.Description = locale.T("workflow_" + workflow.Name)
+		result.Workflows = append(result.Workflows, workflow)
+	}
+
+	return result
+}
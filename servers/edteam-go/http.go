@@ -1,57 +1,72 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"log"
 	"net/http"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
 )
 
-func Request(ctx context.Context, method, url, token string, data any) (int, []byte, error) {
-	var body []byte
-	if data != nil {
-		// If `data` is a slice of bytes, set it directly
-		if b, ok := data.([]byte); ok {
-			body = b
-		} else {
-			var err error
-			body, err = json.Marshal(data)
-			if err != nil {
-				return 0, nil, fmt.Errorf("failed to marshal data: %w", err)
-			}
-		}
-	}
+// HTTPTransport are the settings of the connections to EDteam. The agents
+// call the tools in bursts, the idle connections kept between them save the
+// TLS handshake of the next burst.
+type HTTPTransport struct {
+	// MaxIdleConnsPerHost are the connections kept open to every host, the
+	// default of net/http is 2.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost bounds the connections to every host, zero doesn't.
+	MaxConnsPerHost int
+	// IdleConnTimeout closes the connections idle for longer.
+	IdleConnTimeout time.Duration
+	// HTTP2 multiplexes the requests to a host over one connection when the
+	// host supports it.
+	HTTP2 bool
+}
 
-	req, err := http.NewRequest(method, url, bytes.NewReader(body))
-	if err != nil {
-		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+var defaultHTTPTransport = HTTPTransport{
+	MaxIdleConnsPerHost: 16,
+	IdleConnTimeout:     90 * time.Second,
+	HTTP2:               true,
+}
+
+// newTransport is http.DefaultTransport with the settings of cfg.
+func newTransport(cfg HTTPTransport) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	transport.MaxIdleConns = max(transport.MaxIdleConns, cfg.MaxIdleConnsPerHost)
+	transport.MaxConnsPerHost = cfg.MaxConnsPerHost
+	transport.IdleConnTimeout = cfg.IdleConnTimeout
+	transport.ForceAttemptHTTP2 = cfg.HTTP2
+	transport.Protocols = new(http.Protocols)
+	transport.Protocols.SetHTTP1(true)
+	transport.Protocols.SetHTTP2(cfg.HTTP2)
+
+	return transport
+}
+
+// newEDteamClient calls EDteam with httpClient, reporting the schema drift
+// and the contract changes of the responses. The transport of httpClient is
+// replaced to record or replay the traffic, see VCR_MODE.
+func newEDteamClient(cfg Config, httpClient *http.Client) (*edteam.Client, error) {
+	opts := []edteam.Option{
+		edteam.WithHTTPClient(httpClient),
+		edteam.WithUserAgent("edteam-mcp/" + buildInfo().Version),
+		edteam.WithRetryPolicy(cfg.Retry),
+		edteam.WithMaxResponseSize(int64(cfg.MaxResponseBytes)),
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	if token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	if cfg.EDteamBaseURL != "" {
+		opts = append(opts, edteam.WithBaseURL(cfg.EDteamBaseURL))
 	}
-	req = req.WithContext(ctx)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	client, err := edteam.New(opts...)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("EDTEAM_BASE_URL: %w", err)
 	}
-	defer func(resp *http.Response) {
-		errClose := resp.Body.Close()
-		if errClose != nil {
-			log.Printf("failed to close response body errClose: %v", errClose)
-		}
-	}(resp)
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
+	client.OnDecode = func(ctx context.Context, body []byte, v any) {
+		detectDrift(ctx, body, v)
+		recordContract(ctx, body, v)
 	}
 
-	return resp.StatusCode, respBody, nil
+	return client, nil
 }
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// APIKeyHeader is the header the clients send the API key in, besides the
+// Authorization: Bearer header.
+const APIKeyHeader = "X-API-Key"
+
+// requireAPIKey rejects the requests that don't carry key, before they reach
+// the MCP transport.
+func requireAPIKey(key string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !validAPIKey(key, requestAPIKey(r)) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="edteam-mcp"`)
+			writeHTTPError(w, http.StatusUnauthorized, "missing or invalid API key")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func requestAPIKey(r *http.Request) string {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return strings.TrimSpace(key)
+	}
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+
+	return ""
+}
+
+// validAPIKey compares in constant time, so the key can't be guessed from
+// the response times.
+func validAPIKey(key, got string) bool {
+	return got != "" && subtle.ConstantTimeCompare([]byte(key), []byte(got)) == 1
+}
+
+func writeHTTPError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// corsHeaders are the request headers browser clients may send.
+var corsHeaders = strings.Join([]string{
+	"Content-Type", "Authorization", APIKeyHeader, TokenHeader,
+	"Mcp-Session-Id", "Mcp-Protocol-Version", "Last-Event-ID",
+}, ", ")
+
+// checkOrigin only lets browsers in from the allowed origins, so a website
+// open in the browser can't call a server running on the machine. Requests
+// without Origin don't come from a browser and pass through. Loopback origins
+// are always allowed, and "*" in allowed accepts any origin.
+func checkOrigin(allowed []string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !originAllowed(allowed, origin) {
+			writeHTTPError(w, http.StatusForbidden, "origin not allowed")
+			return
+		}
+
+		header := w.Header()
+		header.Set("Access-Control-Allow-Origin", origin)
+		header.Add("Vary", "Origin")
+		header.Set("Access-Control-Expose-Headers", "Mcp-Session-Id")
+		if r.Method == http.MethodOptions {
+			header.Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+			header.Set("Access-Control-Allow-Headers", corsHeaders)
+			header.Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func originAllowed(allowed []string, origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	if ip := net.ParseIP(u.Hostname()); u.Hostname() == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return true
+	}
+	for _, value := range allowed {
+		if value == "*" || strings.EqualFold(strings.TrimSuffix(value, "/"), origin) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// humanizeDuration renders d as "23 days", "2 months", etc. using the
+// biggest unit that fits.
+func humanizeDuration(d time.Duration, locale Locale) string {
+	if d < 0 {
+		d = -d
+	}
+
+	units := []struct {
+		size time.Duration
+		one  string
+		many string
+	}{
+		{365 * day, "year", "years"},
+		{30 * day, "month", "months_count"},
+		{day, "day", "days"},
+		{time.Hour, "hour", "hours"},
+		{time.Minute, "minute", "minutes"},
+	}
+	for _, unit := range units {
+		if n := int(d / unit.size); n >= 1 {
+			if n == 1 {
+				return locale.T(unit.one, n)
+			}
+			return locale.T(unit.many, n)
+		}
+	}
+
+	return locale.T("minutes", 0)
+}
+
+// relativeTime renders t relative to now, e.g. "in 23 days" or "3 months ago".
+// A zero t, a date EDteam didn't send, renders as "" so the field is left out.
+func relativeTime(now, t time.Time, locale Locale) string {
+	if t.IsZero() {
+		return ""
+	}
+	d := t.Sub(now)
+	if d > -time.Minute && d < time.Minute {
+		return locale.T("now")
+	}
+	if d > 0 {
+		return locale.T("in", humanizeDuration(d, locale))
+	}
+
+	return locale.T("ago", humanizeDuration(d, locale))
+}
+
+func humanizeSubscriptions(subscriptions *SubscriptionsView, now time.Time, locale Locale) {
+	for i := range subscriptions.Data {
+		subscription := &subscriptions.Data[i]
+		subscription.BeginsAtHuman = relativeTime(now, subscription.BeginsAt.Time, locale)
+		switch {
+		case subscription.EndsAt.IsZero():
+			subscription.EndsAtHuman = ""
+		case subscription.EndsAt.After(now):
+			subscription.EndsAtHuman = locale.T("expires", relativeTime(now, subscription.EndsAt.Time, locale))
+		default:
+			subscription.EndsAtHuman = locale.T("expired", relativeTime(now, subscription.EndsAt.Time, locale))
+		}
+	}
+}
+
+func humanizeCourses(courses *CoursesView, now time.Time, locale Locale) {
+	for i := range courses.Data {
+		humanizeCourse(&courses.Data[i], now, locale)
+	}
+}
+
+func humanizeCourse(item *CourseView, now time.Time, locale Locale) {
+	course := &item.Course
+	if course.CreatedAt.IsZero() {
+		course.CreatedAtHuman = ""
+		return
+	}
+	course.CreatedAtHuman = locale.T("published", relativeTime(now, course.CreatedAt.Time, locale))
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+)
+
+func TestHumanizeZeroTimes(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if got := relativeTime(now, time.Time{}, LocaleEN); got != "" {
+		t.Errorf("relativeTime(zero) = %q, want \"\"", got)
+	}
+
+	subscriptions := SubscriptionsView{Data: []SubscriptionView{
+		{Subscription: Subscription{ID: 1, BeginsAt: edteam.Time{Time: now.AddDate(0, 0, -3)}}},
+	}}
+	humanizeSubscriptions(&subscriptions, now, LocaleEN)
+	subscription := subscriptions.Data[0]
+	if subscription.BeginsAtHuman != "3 days ago" || subscription.EndsAtHuman != "" {
+		t.Errorf("humanizeSubscriptions() = %q, %q, want \"3 days ago\", \"\"", subscription.BeginsAtHuman, subscription.EndsAtHuman)
+	}
+	if _, ok := subscriptionField(&subscription, "ends_at_human"); ok {
+		t.Error("the subscription without an end date has ends_at_human")
+	}
+
+	courses := CoursesView{Data: []CourseView{{}}}
+	humanizeCourses(&courses, now, LocaleEN)
+	if got := courses.Data[0].Course.CreatedAtHuman; got != "" {
+		t.Errorf("humanizeCourses() = %q, want \"\"", got)
+	}
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"edteam-mcp/pkg/edteam"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type Locale string
+
+const (
+	LocaleES Locale = "es"
+	LocaleEN Locale = "en"
+)
+
+var messages = map[Locale]map[string]string{
+	LocaleES: {
+		"name":           "Nombre",
+		"level":          "Nivel",
+		"price":          "Precio",
+		"professor":      "Profesor",
+		"duration":       "Duración",
+		"duration_value": "%d clases, %.1f h",
+		"classes":        "Clases",
+		"duration_hours": "Horas",
+		"id":             "ID",
+		"state":          "Estado",
+		"months":         "Meses",
+		"begins":         "Inicio",
+		"ends":           "Fin",
+		"year":           "%d año",
+		"years":          "%d años",
+		"month":          "%d mes",
+		"months_count":   "%d meses",
+		"day":            "%d día",
+		"days":           "%d días",
+		"hour":           "%d hora",
+		"hours":          "%d horas",
+		"minute":         "%d minuto",
+		"minutes":        "%d minutos",
+		"now":            "ahora",
+		"in":             "en %s",
+		"ago":            "hace %s",
+		"expires":        "vence %s",
+		"expired":        "venció %s",
+		"published":      "publicado %s",
+
+		"professors":         "Profesores",
+		"slug":               "Slug",
+		"currency":           "Moneda",
+		"converted_price":    "Precio convertido",
+		"converted_currency": "Moneda convertida",
+
+		"more_results":      "Página %d, hay más resultados: usa el cursor %s para ver la siguiente página.",
+		"last_page_reached": "Esta es la última página.",
+		"no_courses":        "No se encontraron cursos.",
+		"past_last_page":    "No hay más resultados, la última página es la %d.",
+		"past_end":          "No hay más resultados.",
+
+		"access_free":                  "El curso es gratuito.",
+		"access_owned":                 "Compraste este curso, puedes verlo sin suscripción.",
+		"access_included":              "Tu suscripción incluye este curso, vence %s.",
+		"access_requires_purchase":     "No compraste el curso ni tienes una suscripción activa, necesitas comprarlo o suscribirte para verlo.",
+		"course_not_found":             "No se encontró el curso %d en el catálogo.",
+		"course_slug_not_found":        "No se encontró el curso %q en el catálogo.",
+		"subscription_expiring":        "Tu suscripción vence %s y no tiene renovación.",
+		"job_failed":                   "La tarea falló: %s",
+		"study_plan_empty":             "No se encontraron clases para armar el plan, prueba con otro objetivo o indica los cursos con course_ids.",
+		"study_plan_fits":              "El plan termina en %d semanas, antes de la fecha límite.",
+		"study_plan_late":              "El plan necesita %d semanas y no termina antes de la fecha límite; necesitarías unas %d horas por semana.",
+		"study_plan_weeks":             "El plan termina en %d semanas.",
+		"nothing_watched":              "Todavía no has visto ninguna clase, busca un curso con Courses-List para empezar.",
+		"account_email":                "el correo de tu cuenta",
+		"price_history_empty":          "Todavía no hay precios guardados de este curso, se registran cada vez que se consulta el catálogo.",
+		"price_history_lowest":         "El precio actual es el más bajo visto en %d cambios de precio.",
+		"price_history_higher":         "El precio más bajo visto fue %d %s, el actual es más alto; puede convenir esperar una oferta.",
+		"whats_new":                    "%d cursos y %d artículos nuevos desde %s.",
+		"catalog_diff":                 "%d cursos agregados, %d eliminados y %d con cambios desde la copia en caché.",
+		"catalog_diff_empty":           "El catálogo en vivo es igual a la copia en caché.",
+		"justify_purchase":             "Escribe un caso de negocio breve para justificar ante mi empresa la compra del curso %q de EDteam: qué aprenderé, cuánto tiempo me tomará, cuánto cuesta y cómo se compara con las suscripciones que tengo o tuve. Usa solo los datos de abajo, no inventes precios ni fechas.%s\n\nCurso:\n%s\n\nMi historial de suscripciones:\n%s",
+		"justify_purchase_goal":        " El objetivo de la compra es: %s.",
+		"justify_purchase_description": "Caso de negocio para comprar %s",
+		"whats_new_empty":              "No hay contenido nuevo desde %s.",
+		"calendar_subscription_ends":   "Vence tu suscripción de EDteam",
+		"calendar_ready":               "Calendario listo para importar en tu aplicación de calendario.",
+		"confirm_question":             "¿Publicar la pregunta «%s» en la comunidad del curso %d a tu nombre?",
+		"confirm_review":               "¿Publicar tu reseña de %d estrellas del curso %d?",
+		"confirm_required":             "Todavía no se hizo nada. %s Pregúntale al usuario y, si lo aprueba, vuelve a llamar la herramienta con confirm en true.",
+		"confirm_declined":             "El usuario no aprobó la acción, no se hizo nada.",
+		"confirm_export":               "¿Pedir a EDteam una copia de todos los datos de tu cuenta? El enlace de descarga llegará a %s.",
+		"confirm_delete":               "¿Pedir a EDteam que elimine tu cuenta %s? Perderás el acceso a tus cursos, certificados y suscripción; EDteam te enviará un correo para confirmarlo.",
+		"confirm_email_mismatch":       "El correo indicado no coincide con el de la cuenta, no se hizo nada.",
+		"confirm_cart_add":             "¿Agregar «%s» (%s) a tu carrito?",
+		"confirm_gift":                 "¿Regalar «%s» (%s) a %s? Se cobrará a tu cuenta y no se puede deshacer.",
+		"confirm_tool":                 "¿Ejecutar %s con %s?",
+		"unknown_tool":                 "No existe la herramienta %q, llama a Help sin argumentos para ver todas.",
+		"policy_instructions":          "Servidor MCP de EDteam: cursos, suscripción y compras de tu cuenta. Llama a Policy para leer las reglas del operador y a Help para ver cómo combinar las herramientas.",
+		"policy_spending_cap":          "No gastes más de %s por cuenta.",
+		"policy_confirm":               "Las herramientas que cambian algo en EDteam piden la aprobación del usuario antes de ejecutarse.",
+		"policy_confirm_tools":         "Estas herramientas también piden aprobación: %s.",
+		"policy_read_only":             "El servidor es de solo lectura, no puede comprar, publicar ni cambiar nada.",
+		"policy_data_retention":        "El servidor guarda en %s el catálogo, el historial de precios, los cursos seguidos y, en modo daemon, el token de la sesión cifrado; no guarda las conversaciones.",
+		"workflow_buy_course":          "Encontrar un curso, revisar sus detalles, ver si ya lo incluye tu suscripción y agregarlo al carrito; el pago se completa en ed.team.",
+		"workflow_compare_courses":     "Comparar varios cursos en una sola llamada y revisar si su precio suele bajar.",
+		"workflow_wait_for_sale":       "Seguir el precio de un curso y enterarte cuando entre en oferta.",
+		"workflow_keep_learning":       "Retomar la última clase, planificar los próximos cursos y llevar las clases en vivo a tu calendario.",
+		"workflow_export_catalog":      "Exportar el catálogo completo en segundo plano y descargarlo cuando termine.",
+		"workflow_manage_team":         "Ver los miembros de tu equipo, su avance y asignarles cursos.",
+		"workflow_debug_account":       "Averiguar por qué fallan las herramientas de la cuenta: quién eres, el estado del token y de tu suscripción.",
+
+		"next_step_auth":            "Revisa que EMAIL y PASSWORD sean correctos y que tu cuenta tenga acceso a este recurso.",
+		"next_step_not_found":       "Verifica el identificador, por ejemplo listando los cursos con Courses-List.",
+		"next_step_rate_limited":    "Espera unos segundos antes de volver a intentarlo.",
+		"next_step_upstream_down":   "EDteam no está disponible en este momento, inténtalo más tarde.",
+		"next_step_invalid_request": "Revisa los argumentos enviados a la herramienta.",
+		"next_step_unknown":         "Inténtalo de nuevo y, si el problema continúa, revisa los logs del servidor.",
+		"next_step_cancelled":       "La llamada fue cancelada por el cliente, no se necesita hacer nada.",
+
+		"error_session_expired":          "La sesión expiró, volviendo a autenticar.",
+		"error_forbidden":                "Tu plan no permite esta acción.",
+		"error_rate_limited":             "Se alcanzó el límite de peticiones, inténtalo más tarde.",
+		"next_step_session_expired":      "El servidor volvió a iniciar sesión y no funcionó; revisa EMAIL y PASSWORD antes de reintentar.",
+		"next_step_forbidden":            "No reintentes, revisa tu suscripción con la herramienta Subscriptions.",
+		"error_ambiguous":                "No se sabe si EDteam procesó la petición.",
+		"next_step_ambiguous":            "No reintentes automáticamente; revisa tu carrito de compras antes de volver a intentarlo.",
+		"error_timeout":                  "EDteam no respondió a tiempo.",
+		"next_step_timeout":              "Inténtalo de nuevo en unos momentos.",
+		"error_missing_token":            "Este servidor atiende a varios usuarios y la sesión no envió su token de EDteam.",
+		"next_step_missing_token":        "Configura el cliente MCP para enviar tu token de EDteam en la cabecera X-EDteam-Token.",
+		"error_token_rejected":           "EDteam rechazó el token de la sesión.",
+		"next_step_token_rejected":       "El token venció o no es válido; inicia sesión en EDteam de nuevo y actualiza el token del cliente MCP.",
+		"error_tool_rate_limited":        "Se alcanzó el límite de llamadas de esta herramienta configurado en el servidor.",
+		"next_step_tool_rate_limited":    "Espera los segundos de retry_after_seconds antes de volver a llamarla y no la llames en bucle.",
+		"error_spending_cap":             "Comprar este curso supera el límite de gasto de la cuenta configurado en el servidor.",
+		"next_step_spending_cap":         "No lo compres. Dile al usuario que alcanzó el límite de gasto, puede comprarlo él mismo en EDteam.",
+		"error_endpoint_unavailable":     "EDteam no ofrece esta función por su API, el endpoint no existe o cambió.",
+		"next_step_endpoint_unavailable": "No reintentes. Dile al usuario que lo haga desde la web de EDteam.",
+		"error_busy":                     "El servidor está atendiendo demasiadas llamadas a la vez.",
+		"next_step_busy":                 "Haz las llamadas de una en una o espera unos segundos antes de reintentar.",
+		"error_too_many_jobs":            "Ya tienes el máximo de tareas en segundo plano en curso.",
+		"next_step_too_many_jobs":        "Sigue las tareas en curso con Job-Status y espera a que una termine antes de iniciar otra.",
+		"error_response_too_large":       "La respuesta de EDteam superó el tamaño máximo configurado en el servidor.",
+		"next_step_response_too_large":   "No reintentes con los mismos argumentos; pide menos datos, como una página más pequeña, o sube MAX_RESPONSE_BYTES.",
+	},
+	LocaleEN: {
+		"name":           "Name",
+		"level":          "Level",
+		"price":          "Price",
+		"professor":      "Professor",
+		"duration":       "Duration",
+		"duration_value": "%d classes, %.1f h",
+		"classes":        "Classes",
+		"duration_hours": "Hours",
+		"id":             "ID",
+		"state":          "State",
+		"months":         "Months",
+		"begins":         "Begins",
+		"ends":           "Ends",
+		"year":           "%d year",
+		"years":          "%d years",
+		"month":          "%d month",
+		"months_count":   "%d months",
+		"day":            "%d day",
+		"days":           "%d days",
+		"hour":           "%d hour",
+		"hours":          "%d hours",
+		"minute":         "%d minute",
+		"minutes":        "%d minutes",
+		"now":            "now",
+		"in":             "in %s",
+		"ago":            "%s ago",
+		"expires":        "expires %s",
+		"expired":        "expired %s",
+		"published":      "published %s",
+
+		"professors":         "Professors",
+		"slug":               "Slug",
+		"currency":           "Currency",
+		"converted_price":    "Converted price",
+		"converted_currency": "Converted currency",
+
+		"more_results":      "Page %d, there are more results: use the cursor %s to get the next page.",
+		"last_page_reached": "This is the last page.",
+		"no_courses":        "No courses found.",
+		"past_last_page":    "No more results, the last page is %d.",
+		"past_end":          "No more results.",
+
+		"access_free":                  "The course is free.",
+		"access_owned":                 "You bought this course, you can watch it without a subscription.",
+		"access_included":              "Your subscription includes this course, it expires %s.",
+		"access_requires_purchase":     "You didn't buy the course and don't have an active subscription, you need to buy it or subscribe to watch it.",
+		"course_not_found":             "Course %d was not found in the catalog.",
+		"course_slug_not_found":        "Course %q was not found in the catalog.",
+		"subscription_expiring":        "Your subscription expires %s and has no renewal.",
+		"job_failed":                   "The job failed: %s",
+		"study_plan_empty":             "No classes were found to build the plan, try another goal or choose the courses with course_ids.",
+		"study_plan_fits":              "The plan takes %d weeks and ends before the deadline.",
+		"study_plan_late":              "The plan needs %d weeks and doesn't end before the deadline; you would need about %d hours per week.",
+		"study_plan_weeks":             "The plan takes %d weeks.",
+		"nothing_watched":              "You haven't watched any class yet, look for a course with Courses-List to start.",
+		"account_email":                "your account email",
+		"price_history_empty":          "There are no prices stored for this course yet, they are recorded every time the catalog is fetched.",
+		"price_history_lowest":         "The current price is the lowest seen in %d price changes.",
+		"price_history_higher":         "The lowest price seen was %d %s, the current one is higher; waiting for a sale may pay off.",
+		"whats_new":                    "%d new courses and %d new articles since %s.",
+		"catalog_diff":                 "%d courses added, %d removed and %d changed since the cached copy.",
+		"catalog_diff_empty":           "The live catalog matches the cached copy.",
+		"justify_purchase":             "Write a short business case to justify to my company buying the EDteam course %q: what I will learn, how long it will take, what it costs and how it compares with the subscriptions I have or had. Use only the data below, don't make up prices or dates.%s\n\nCourse:\n%s\n\nMy subscription history:\n%s",
+		"justify_purchase_goal":        " The purchase is for: %s.",
+		"justify_purchase_description": "Business case for buying %s",
+		"whats_new_empty":              "There is no new content since %s.",
+		"calendar_subscription_ends":   "Your EDteam subscription ends",
+		"calendar_ready":               "Calendar ready to import in your calendar app.",
+		"confirm_question":             "Post the question \"%s\" in the community of course %d under your name?",
+		"confirm_review":               "Post your %d star review of course %d?",
+		"confirm_required":             "Nothing was done yet. %s Ask the user and, if they approve, call the tool again with confirm set to true.",
+		"confirm_declined":             "The user didn't approve the action, nothing was done.",
+		"confirm_export":               "Ask EDteam for a copy of all your account data? The download link will be sent to %s.",
+		"confirm_delete":               "Ask EDteam to delete your account %s? You will lose access to your courses, certificates and subscription; EDteam will email you to confirm it.",
+		"confirm_email_mismatch":       "The email doesn't match the account email, nothing was done.",
+		"confirm_cart_add":             "Add \"%s\" (%s) to your cart?",
+		"confirm_gift":                 "Gift \"%s\" (%s) to %s? It is charged to your account and can't be undone.",
+		"confirm_tool":                 "Run %s with %s?",
+		"unknown_tool":                 "There is no tool %q, call Help without arguments to see them all.",
+		"policy_instructions":          "EDteam MCP server: the courses, subscription and purchases of your account. Call Policy to read the rules of the operator and Help to see how to combine the tools.",
+		"policy_spending_cap":          "Don't spend more than %s for the account.",
+		"policy_confirm":               "The tools that change something in EDteam ask the user to approve them before running.",
+		"policy_confirm_tools":         "These tools ask for approval too: %s.",
+		"policy_read_only":             "The server is read-only, it can't buy, post or change anything.",
+		"policy_data_retention":        "The server keeps the catalog, the price history, the watched courses and, in daemon mode, the encrypted session token in %s; it doesn't keep the conversations.",
+		"workflow_buy_course":          "Find a course, review its details, check whether your subscription already includes it and add it to the cart; the payment is completed on ed.team.",
+		"workflow_compare_courses":     "Compare several courses in a single call and check whether their price usually drops.",
+		"workflow_wait_for_sale":       "Follow the price of a course and find out when it goes on sale.",
+		"workflow_keep_learning":       "Resume the last class, plan the next courses and take the live classes to your calendar.",
+		"workflow_export_catalog":      "Export the full catalog in the background and download it when it finishes.",
+		"workflow_manage_team":         "See the members of your team, their progress and assign them courses.",
+		"workflow_debug_account":       "Find out why the tools of the account fail: who you are, the state of the token and of your subscription.",
+
+		"next_step_auth":            "Check that EMAIL and PASSWORD are right and that your account can access this resource.",
+		"next_step_not_found":       "Verify the identifier, for example by listing the courses with Courses-List.",
+		"next_step_rate_limited":    "Wait a few seconds before trying again.",
+		"next_step_upstream_down":   "EDteam is not available right now, try again later.",
+		"next_step_invalid_request": "Review the arguments sent to the tool.",
+		"next_step_unknown":         "Try again and, if the problem persists, check the server logs.",
+		"next_step_cancelled":       "The call was cancelled by the client, nothing else to do.",
+
+		"error_session_expired":          "Session expired, re-authenticating.",
+		"error_forbidden":                "Your plan doesn't allow this.",
+		"error_rate_limited":             "Rate limited, retry later.",
+		"next_step_session_expired":      "The server logged in again and it didn't work; check EMAIL and PASSWORD before retrying.",
+		"next_step_forbidden":            "Don't retry, review your subscription with the Subscriptions tool.",
+		"error_ambiguous":                "It is unknown whether EDteam processed the request.",
+		"next_step_ambiguous":            "Don't retry automatically; check your shopping cart before trying again.",
+		"error_timeout":                  "EDteam didn't answer in time.",
+		"next_step_timeout":              "Try again in a few moments.",
+		"error_missing_token":            "This server serves several users and the session didn't send its EDteam token.",
+		"next_step_missing_token":        "Configure the MCP client to send your EDteam token in the X-EDteam-Token header.",
+		"error_token_rejected":           "EDteam rejected the token of the session.",
+		"next_step_token_rejected":       "The token expired or is invalid; log in to EDteam again and update the token of the MCP client.",
+		"error_tool_rate_limited":        "The server limit of calls to this tool was reached.",
+		"next_step_tool_rate_limited":    "Wait retry_after_seconds seconds before calling it again and don't call it in a loop.",
+		"error_spending_cap":             "Buying this course goes over the spending cap of the account set by the server.",
+		"next_step_spending_cap":         "Don't buy it. Tell the user the spending cap was reached, they can buy it themselves in EDteam.",
+		"error_endpoint_unavailable":     "EDteam doesn't offer this through its API, the endpoint doesn't exist or moved.",
+		"next_step_endpoint_unavailable": "Don't retry. Tell the user to do it on the EDteam website.",
+		"error_busy":                     "The server is handling too many calls at the same time.",
+		"next_step_busy":                 "Make the calls one at a time or wait a few seconds before retrying.",
+		"error_too_many_jobs":            "You already have the most background jobs running.",
+		"next_step_too_many_jobs":        "Follow the running jobs with Job-Status and wait for one to finish before starting another.",
+		"error_response_too_large":       "The EDteam response is larger than the maximum size configured in the server.",
+		"next_step_response_too_large":   "Don't retry with the same arguments; ask for less data, like a smaller page, or raise MAX_RESPONSE_BYTES.",
+	},
+}
+
+// ParseLocale accepts values like "es", "en", "es-PE" or "en_US".
+func ParseLocale(value string) (Locale, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if i := strings.IndexAny(value, "-_"); i > 0 {
+		value = value[:i]
+	}
+
+	locale := Locale(value)
+	if _, ok := messages[locale]; !ok {
+		return "", false
+	}
+
+	return locale, true
+}
+
+// T returns the message of the given key in the locale, falling back to
+// English and then to the key itself.
+func (l Locale) T(key string, args ...any) string {
+	message, ok := messages[l][key]
+	if !ok {
+		message, ok = messages[LocaleEN][key]
+	}
+	if !ok {
+		message = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+
+	return message
+}
+
+func localeOption() mcp.ToolOption {
+	return mcp.WithString("locale", mcp.Description("Language of the response, defaults to the LOCALE environment variable"), mcp.Enum(string(LocaleES), string(LocaleEN)))
+}
+
+type localeKey struct{}
+
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	ctx = edteam.WithLanguage(ctx, string(locale))
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+func LocaleFromContext(ctx context.Context) (Locale, bool) {
+	locale, ok := ctx.Value(localeKey{}).(Locale)
+	return locale, ok
+}
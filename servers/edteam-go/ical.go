@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const icalTimestamp = "20060102T150405Z"
+
+// calendarICS renders the upcoming live events and the end of the active
+// subscription as an iCalendar (RFC 5545) payload.
+func calendarICS(events LiveEventsResponse, subscriptions SubscriptionResponse, now time.Time, locale Locale) string {
+	var b strings.Builder
+	line := func(format string, args ...any) {
+		writeICSLine(&b, fmt.Sprintf(format, args...))
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//EDteam//EDteam MCP//%s", strings.ToUpper(string(locale)))
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	stamp := now.UTC().Format(icalTimestamp)
+
+	for _, event := range events.Data {
+		if event.StartsAt.Before(now) {
+			continue
+		}
+		ends := event.StartsAt.Add(time.Duration(event.Duration) * time.Minute)
+		line("BEGIN:VEVENT")
+		line("UID:live-%d@ed.team", event.ID)
+		line("DTSTAMP:%s", stamp)
+		line("DTSTART:%s", event.StartsAt.UTC().Format(icalTimestamp))
+		line("DTEND:%s", ends.UTC().Format(icalTimestamp))
+		line("SUMMARY:%s", escapeICS(event.Title))
+		if event.Description != "" {
+			line("DESCRIPTION:%s", escapeICS(event.Description))
+		}
+		if event.URL != "" {
+			line("URL:%s", event.URL)
+			line("LOCATION:%s", escapeICS(event.URL))
+		}
+		line("END:VEVENT")
+	}
+
+	if subscription, ok := activeSubscription(subscriptions, now); ok {
+		ends := subscription.EndsAt
+		line("BEGIN:VEVENT")
+		line("UID:subscription-%d@ed.team", subscription.ID)
+		line("DTSTAMP:%s", stamp)
+		line("DTSTART;VALUE=DATE:%s", ends.Format("20060102"))
+		line("DTEND;VALUE=DATE:%s", ends.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:%s", escapeICS(locale.T("calendar_subscription_ends")))
+		line("URL:https://ed.team/premium")
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+
+	return b.String()
+}
+
+// escapeICS escapes the characters with a meaning in iCalendar text values.
+func escapeICS(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// writeICSLine writes a content line folded at 75 octets, without splitting
+// UTF-8 characters, and ended with CRLF as RFC 5545 requires.
+func writeICSLine(b *strings.Builder, s string) {
+	const limit = 75
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+const (
+	// jobTimeout bounds a job, which outlives the tool call that started it.
+	jobTimeout = 15 * time.Minute
+	// jobRetention is how long a finished job can be read with Job-Result.
+	jobRetention = time.Hour
+	// maxRunningJobs bounds the jobs running at the same time for a caller.
+	// They run outside the CallSlots of the tool calls, a job like the
+	// catalog export walks every course.
+	maxRunningJobs = 2
+)
+
+// ErrJobNotFound means the job doesn't exist, expired or belongs to another
+// client session.
+var ErrJobNotFound = errors.New("the job doesn't exist or expired")
+
+// ErrTooManyJobs is returned when the caller already has maxRunningJobs
+// running.
+var ErrTooManyJobs = errors.New("too many jobs running, wait for one to finish")
+
+// JobInfo is what Job-Status reports about a job.
+type JobInfo struct {
+	ID         string     `json:"job_id"`
+	Tool       string     `json:"tool"`
+	Status     string     `json:"status"`
+	Progress   int        `json:"progress"`
+	Total      int        `json:"total,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+type job struct {
+	info    JobInfo
+	owner   string
+	caller  string
+	result  *mcp.CallToolResult
+	expires time.Time
+}
+
+// JobFunc does the work of a job, calling progress as it goes.
+type JobFunc func(ctx context.Context, progress func(done, total int)) (*mcp.CallToolResult, error)
+
+// Jobs runs the long operations in the background, so the tool call returns
+// a job ID before the client gives up on it.
+type Jobs struct {
+	mu   sync.Mutex
+	jobs map[string]*job
+}
+
+func NewJobs() *Jobs {
+	return &Jobs{jobs: make(map[string]*job)}
+}
+
+// Start runs fn in the background for the client session of ctx. The job
+// keeps the values of ctx, like the EDteam token of the session, but not its
+// cancellation. The progress is sent to the client when the call asked for
+// it with a progress token.
+//
+// A caller runs up to maxRunningJobs at the same time, counted by callerKey
+// so reconnecting doesn't reset it. The call that starts a job is never
+// answered from the result cache, every call starts its own job.
+func (j *Jobs) Start(ctx context.Context, request mcp.CallToolRequest, fn JobFunc) (JobInfo, error) {
+	skipResultCache(ctx)
+	id := newJobID()
+	now := time.Now()
+	entry := &job{
+		info:   JobInfo{ID: id, Tool: request.Params.Name, Status: JobRunning, StartedAt: now},
+		owner:  clientSessionID(ctx),
+		caller: callerKey(ctx),
+	}
+
+	j.mu.Lock()
+	j.prune(now)
+	if j.running(entry.caller) >= maxRunningJobs {
+		j.mu.Unlock()
+		return JobInfo{}, ErrTooManyJobs
+	}
+	j.jobs[id] = entry
+	info := entry.info
+	j.mu.Unlock()
+
+	var progressToken mcp.ProgressToken
+	if request.Params.Meta != nil {
+		progressToken = request.Params.Meta.ProgressToken
+	}
+	mcpServer := server.ServerFromContext(ctx)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jobTimeout)
+
+	go func() {
+		defer cancel()
+		progress := func(done, total int) {
+			j.mu.Lock()
+			entry.info.Progress = done
+			entry.info.Total = total
+			j.mu.Unlock()
+			if progressToken != nil && mcpServer != nil {
+				sendProgress(ctx, mcpServer, progressToken, done, total)
+			}
+		}
+
+		result, err := fn(ctx, progress)
+
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		finished := time.Now()
+		entry.info.FinishedAt = &finished
+		entry.expires = finished.Add(jobRetention)
+		if err != nil {
+			entry.info.Status = JobFailed
+			entry.info.Error = err.Error()
+			slog.Warn("job failed", "job", id, "tool", entry.info.Tool, "error", err)
+			return
+		}
+		entry.info.Status = JobDone
+		entry.result = result
+	}()
+
+	return info, nil
+}
+
+// running counts the jobs of caller still running, j.mu must be held.
+func (j *Jobs) running(caller string) int {
+	n := 0
+	for _, entry := range j.jobs {
+		if entry.caller == caller && entry.info.Status == JobRunning {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Get returns the job id started by the client session of ctx.
+func (j *Jobs) Get(ctx context.Context, id string) (JobInfo, *mcp.CallToolResult, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.prune(time.Now())
+	entry, ok := j.jobs[id]
+	if !ok || entry.owner != clientSessionID(ctx) {
+		return JobInfo{}, nil, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+
+	return entry.info, entry.result, nil
+}
+
+// prune forgets the jobs finished more than jobRetention ago, j.mu must be
+// held.
+func (j *Jobs) prune(now time.Time) {
+	for id, entry := range j.jobs {
+		if entry.info.Status != JobRunning && now.After(entry.expires) {
+			delete(j.jobs, id)
+		}
+	}
+}
+
+func sendProgress(ctx context.Context, s *server.MCPServer, token mcp.ProgressToken, done, total int) {
+	totalFloat := float64(total)
+	notification := mcp.NewProgressNotification(token, float64(done), &totalFloat, nil)
+	err := s.SendNotificationToClient(ctx, notification.Method, map[string]any{
+		"progressToken": notification.Params.ProgressToken,
+		"progress":      notification.Params.Progress,
+		"total":         notification.Params.Total,
+	})
+	if err != nil {
+		slog.Debug("failed to send the job progress", "error", err)
+	}
+}
+
+func newJobID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
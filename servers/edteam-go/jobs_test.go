@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type jobValueKey struct{}
+
+// TestJobOutlivesCall cancels the call that started a job: the job keeps
+// the values of the context but not its cancellation.
+func TestJobOutlivesCall(t *testing.T) {
+	jobs := NewJobs()
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), jobValueKey{}, "token"))
+
+	started := make(chan struct{})
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "Courses-Details"
+	info, err := jobs.Start(ctx, request, func(ctx context.Context, progress func(done, total int)) (*mcp.CallToolResult, error) {
+		<-started
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+			t.Error("the job has no deadline, want jobTimeout")
+		}
+		progress(1, 1)
+		return mcp.NewToolResultText(ctx.Value(jobValueKey{}).(string)), nil
+	})
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	cancel()
+	close(started)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got, result, err := jobs.Get(context.Background(), info.ID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.Status != JobRunning {
+			if got.Status != JobDone || mcp.GetTextFromContent(result.Content[0]) != "token" {
+				t.Errorf("Get() = %+v, want the job done with the value of the context", got)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the job is still running")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestJobsBoundedPerCaller starts more jobs than maxRunningJobs through a
+// cached tool: every call starts a job until the limit, none is answered
+// from the cache.
+func TestJobsBoundedPerCaller(t *testing.T) {
+	jobs := NewJobs()
+	release := make(chan struct{})
+	defer close(release)
+
+	readOnly := true
+	tool := mcp.Tool{Name: "Export-CSV"}
+	tool.Annotations.ReadOnlyHint = &readOnly
+	handler := cacheResults(time.Minute, nil)(tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		info, err := jobs.Start(ctx, request, func(ctx context.Context, progress func(done, total int)) (*mcp.CallToolResult, error) {
+			<-release
+			return mcp.NewToolResultText("done"), nil
+		})
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(info.ID), nil
+	})
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = tool.Name
+	request.Params.Arguments = map[string]any{"dataset": "courses"}
+	ids := make(map[string]bool)
+	for range maxRunningJobs {
+		result, err := handler(context.Background(), request)
+		if err != nil || result.IsError {
+			t.Fatalf("call = %v, %v, want a job started", result, err)
+		}
+		ids[mcp.GetTextFromContent(result.Content[0])] = true
+	}
+	if len(ids) != maxRunningJobs {
+		t.Errorf("started %d jobs, want %d: the start of a job was cached", len(ids), maxRunningJobs)
+	}
+
+	result, err := handler(context.Background(), request)
+	if err != nil || !result.IsError {
+		t.Errorf("call = %v, %v, want ErrTooManyJobs", result, err)
+	}
+	if _, err := jobs.Start(context.Background(), request, nil); !errors.Is(err, ErrTooManyJobs) {
+		t.Errorf("Start() error = %v, want ErrTooManyJobs", err)
+	}
+}
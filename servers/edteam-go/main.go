@@ -2,115 +2,335 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"edteam-mcp/vcr"
 
-	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
 func main() {
 	log.SetOutput(os.Stderr)
 
-	email := os.Getenv("EMAIL")
-	password := os.Getenv("PASSWORD")
-	if email == "" || password == "" {
-		panic("EMAIL and PASSWORD environment variables must be set")
+	flags, err := parseFlags(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+
+	deps := Deps{Stdout: os.Stdout, Serve: serve}
+	if err := run(context.Background(), flags, deps); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Deps are the dependencies of run on the outside world. main passes the
+// real ones, a test can serve in process and answer for EDteam.
+type Deps struct {
+	Stdout io.Writer
+	// Transport sends the requests to EDteam, nil uses a transport with the
+	// HTTP settings of the config.
+	Transport http.RoundTripper
+	// Serve serves s until the client disconnects or the process is told
+	// to stop. The http transports serve metrics too.
+	Serve func(s *server.MCPServer, cfg Config, metrics *Metrics) error
+}
+
+// errContractChanged is returned by --check-contract when the EDteam
+// responses don't match the models, the changes are written to Stdout.
+var errContractChanged = errors.New("the EDteam responses don't match the models")
+
+// run loads the config, sets up the server and serves it with deps.Serve.
+// It returns when serving ends, the configuration problems are returned
+// together instead of one at a time.
+func run(ctx context.Context, flags Flags, deps Deps) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var problems ConfigError
+	var fileKeys []string
+	if flags.ConfigFile != "" {
+		var err error
+		fileKeys, err = loadEnvFile(flags.ConfigFile)
+		problems.add(err)
 	}
 
-	ctx := context.Background()
-	token, err := ProcessLogin(ctx, email, password)
+	cfg, err := LoadConfig()
+	problems.add(err)
+	problems.add(flags.apply(&cfg))
+
+	// The client is built before the checks of the config, the caches hold
+	// it. Its transport is set once the config is valid.
+	httpClient := &http.Client{}
+	client, err := newEDteamClient(cfg, httpClient)
 	if err != nil {
-		panic(err)
+		problems.add(err)
+		return problems.err()
+	}
+	srv := &Server{
+		client:    client,
+		catalog:   NewCatalog(client.Courses, cfg.CatalogTTL, cfg.MaxPageSize, cfg.Locale),
+		durations: NewDurations(client.Courses, cfg.CatalogTTL),
+		slots:     NewCallSlots(cfg.MaxConcurrentCalls, cfg.QueueTimeout),
+		jobs:      NewJobs(),
+		watchlist: NewWatchlist(cfg.DataDir),
+		spending:  NewSpending(),
+		calls:     NewCallHistory(),
+		metrics:   NewMetrics(),
 	}
+	srv.setInstructions(cfg)
+	hooks := &server.Hooks{}
+	hooks.AddAfterInitialize(srv.sendInstructions)
+	index := NewSearchIndex(srv.catalog.Courses)
+	srv.catalog.OnFetch(index.Update)
+	completions := NewCompletions(index)
 
 	// Create a new MCP server
 	s := server.NewMCPServer(
 		"EDteam API",
-		"1.0.0",
-		server.WithToolCapabilities(false),
+		buildInfo().Version,
+		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(false, false),
 		server.WithLogging(),
+		server.WithElicitation(),
+		server.WithHooks(hooks),
+		server.WithCompletions(),
+		server.WithPromptCompletionProvider(completions),
+		server.WithResourceCompletionProvider(completions),
 	)
+	srv.mcp = s
+	s.AddResourceTemplate(courseResource(srv.catalog))
 
-	subscriptionsTool := mcp.NewTool(
-		"Subscriptions",
-		mcp.WithDescription("List all your subscriptions in the history of EDteam"),
-	)
-
-	s.AddTool(subscriptionsTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		subscriptions, err := GetSubscription(ctx, token)
-		if err != nil {
-			return nil, err
-		}
-		var subscriptionsRaw []byte
-		subscriptionsRaw, err = json.Marshal(subscriptions)
-		if err != nil {
-			return nil, err
+	tools, err := exposedTools(srv.buildTools(cfg), cfg)
+	problems.add(err)
+	problems.add(validateConfig(cfg, tools))
+	if cfg.Transport != TransportStdio && problems.err() == nil {
+		problems.add(checkListen(cfg.Listen))
+	}
+	base := deps.Transport
+	if base == nil {
+		base = newTransport(cfg.HTTP)
+	}
+	httpClient.Transport = base
+	if cfg.VCRMode != "" && cfg.VCRCassette != "" {
+		transport, err := vcr.New(cfg.VCRMode, cfg.VCRCassette, base)
+		if err == nil {
+			httpClient.Transport = transport
 		}
+		problems.add(err)
+	}
+	if cfg.Chaos.Enabled() {
+		httpClient.Transport = newChaosTransport(cfg.Chaos, httpClient.Transport)
+	}
+	if !cfg.Telemetry {
+		httpClient.Transport = newEDteamOnlyTransport(httpClient.Transport)
+	}
+	if err := problems.err(); err != nil {
+		return err
+	}
+	srv.logLevel = setupLogging(cfg.LogLevel)
+	if cfg.Chaos.Enabled() {
+		slog.Warn("injecting failures into the requests to EDteam", "latency", cfg.Chaos.Latency, "error_rate", cfg.Chaos.ErrorRate, "reset_rate", cfg.Chaos.ResetRate)
+	}
+	if !cfg.Telemetry {
+		slog.Info("telemetry is off, the metrics are disabled and only EDteam is called")
+	}
 
-		return mcp.NewToolResultText(string(subscriptionsRaw)), nil
-	})
+	if flags.CheckContract {
+		if checkContract(ctx, client, cfg, deps.Stdout) != 0 {
+			return errContractChanged
+		}
+		return nil
+	}
 
-	coursesListTool := mcp.NewTool(
-		"Courses-List",
-		mcp.WithDescription("List all courses of EDteam"),
-		mcp.WithNumber("page", mcp.Description("Page number"), mcp.DefaultNumber(1)),
-		mcp.WithNumber("limit", mcp.Description("Limit number of courses"), mcp.DefaultNumber(10)),
-	)
-	s.AddTool(coursesListTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		page, ok := request.Params.Arguments["page"].(float64)
-		if !ok {
-			page = 1
+	if flags.DebugPprof != "" {
+		if err := servePprof(ctx, flags.DebugPprof); err != nil {
+			return err
 		}
-		limit, ok := request.Params.Arguments["limit"].(float64)
-		if !ok || limit <= 0 || limit > 10 {
-			limit = 10
+	}
+
+	if cfg.PIDFile != "" {
+		if err := writePIDFile(cfg.PIDFile); err != nil {
+			return err
 		}
+		defer removePIDFile(cfg.PIDFile)
+	}
 
-		courses, err := GetCourses(ctx, uint(page), uint(limit))
-		if err != nil {
-			return nil, err
+	switch {
+	case cfg.MultiTenant || cfg.Email == "" || cfg.Password == "":
+		// Without credentials validateConfig only let the public tools in,
+		// they never use the session.
+		srv.session = NewTenantSession()
+	case cfg.Daemon:
+		cipher, errCipher := NewTokenCipher(cfg.TokenPassphrase)
+		if errCipher != nil {
+			slog.Warn("the session token is kept in memory, set TOKEN_PASSPHRASE to persist it encrypted", "error", errCipher)
+			srv.session, err = NewSession(ctx, client, cfg.Email, cfg.Password)
+			break
 		}
+		srv.session, err = RestoreSession(ctx, client, cfg.Email, cfg.Password, filepath.Join(cfg.DataDir, "token.json"), cipher)
+	default:
+		srv.session, err = NewSession(ctx, client, cfg.Email, cfg.Password)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to log in to EDteam: %w", err)
+	}
 
-		var coursesRaw []byte
-		coursesRaw, err = json.Marshal(courses)
+	if cfg.CurrencyRates != "" {
+		srv.rates, err = LoadRates(ctx, client, cfg.CurrencyRates)
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("failed to load CURRENCY_RATES: %w", err)
 		}
+	}
+
+	srv.prices, err = OpenPriceHistory(filepath.Join(cfg.DataDir, "prices.db"))
+	if err != nil {
+		return fmt.Errorf("failed to open the price history: %w", err)
+	}
+	defer srv.prices.Close()
 
-		// Create a response
-		return mcp.NewToolResultText(string(coursesRaw)), nil
-	})
+	srv.catalog.OnFetch(recordPrices(srv.prices))
+	if cfg.Daemon {
+		srv.catalog.Persist(filepath.Join(cfg.DataDir, "catalog.json"))
+	}
 
-	shoppingCartTool := mcp.NewTool(
-		"Shopping-Cart-Add-Course",
-		mcp.WithDescription("Add a course to your shopping cart"),
-		mcp.WithNumber("course_id", mcp.Description("Course ID"), mcp.DefaultNumber(0), mcp.Required()),
-	)
-	s.AddTool(shoppingCartTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		courseID, ok := request.Params.Arguments["course_id"].(float64)
-		if !ok {
-			return nil, fmt.Errorf("course_id must be a number")
+	s.SetTools(tools...)
+	// A multi-tenant session gets the token of every read from the client.
+	// The prompt needs the subscriptions too.
+	if cfg.MultiTenant || srv.session.CanCall(ctx) {
+		s.AddResources(subscriptionResources(client, srv.session, cfg.Locale)...)
+		s.AddPrompt(justifyPurchasePrompt(client, srv.catalog, srv.session, cfg.Locale))
+	}
+
+	var reload <-chan struct{}
+	if cfg.Daemon {
+		reload = reloadSignals()
+		if flags.ConfigFile == "" {
+			go func() {
+				for range reload {
+					slog.Warn("SIGHUP received but there is no --config file to reload")
+				}
+			}()
 		}
+	}
+	if flags.ConfigFile != "" {
+		go watchConfig(ctx, flags, cfg, fileKeys, reload, func(cfg Config) error {
+			tools, err := exposedTools(srv.buildTools(cfg), cfg)
+			if err != nil {
+				return err
+			}
+			if err := validateConfig(cfg, tools); err != nil {
+				return err
+			}
+			replaceTools(s, tools)
+			srv.setInstructions(cfg)
+			srv.logLevel.Set(logLevels[cfg.LogLevel])
+			return nil
+		})
+	}
 
-		shoppingCart, err := AddCourseToShoppingCart(ctx, token, int(courseID))
-		if err != nil {
-			return nil, err
+	notifier := NewNotifier(s, client, srv.session, cfg)
+	var tasks []Task
+	switch {
+	case cfg.SyncSchedule != nil:
+		tasks = append(tasks, Task{Name: "sync", Schedule: cfg.SyncSchedule})
+	case cfg.SyncInterval > 0:
+		tasks = append(tasks, Task{Name: "sync", Schedule: Every(cfg.SyncInterval)})
+	}
+	if len(tasks) > 0 {
+		// The watchlist of a multi-tenant server would notify the sales to
+		// every tenant, Watch-Course isn't exposed.
+		watchlist := srv.watchlist
+		if cfg.MultiTenant {
+			watchlist = nil
+		}
+		tasks[0].Run = func(ctx context.Context) {
+			syncCatalog(ctx, notifier, srv.catalog, watchlist, cfg.CurrencyCodes)
 		}
+		// The courses already published when the server starts aren't new.
+		go func() {
+			if courses, err := srv.catalog.Courses(ctx); err == nil {
+				notifier.NewCourses(courses)
+			}
+		}()
+	}
+	if cfg.DigestSchedule != nil {
+		tasks = append(tasks, Task{Name: "digest", Schedule: cfg.DigestSchedule, Run: func(ctx context.Context) {
+			notifier.Digest(ctx, srv.catalog, time.Now())
+		}})
+	}
+	runSchedule(ctx, tasks, cfg.ScheduleJitter)
 
-		var shoppingCartRaw []byte
-		shoppingCartRaw, err = json.Marshal(shoppingCart)
-		if err != nil {
-			return nil, err
+	return deps.Serve(s, cfg, srv.metrics)
+}
+
+func serve(s *server.MCPServer, cfg Config, metrics *Metrics) error {
+	var handler http.Handler
+	switch cfg.Transport {
+	case TransportSSE:
+		var opts []server.SSEOption
+		if cfg.MultiTenant {
+			opts = append(opts, server.WithSSEContextFunc(tokenFromRequest))
+		}
+		handler = server.NewSSEServer(s, opts...)
+	case TransportHTTP:
+		var opts []server.StreamableHTTPOption
+		if cfg.MultiTenant {
+			opts = append(opts, server.WithHTTPContextFunc(tokenFromRequest))
 		}
+		handler = server.NewStreamableHTTPServer(s, opts...)
+	default:
+		return server.ServeStdio(s)
+	}
 
-		// Create a response
-		return mcp.NewToolResultText(string(shoppingCartRaw)), nil
-	})
+	mux := http.NewServeMux()
+	if cfg.Telemetry {
+		mux.Handle("/metrics", metrics)
+	}
+	mux.Handle("/", handler)
+	handler = mux
 
-	if err := server.ServeStdio(s); err != nil {
-		panic(err)
+	if cfg.APIKey != "" {
+		handler = requireAPIKey(cfg.APIKey, handler)
 	}
+	// The origin is checked first, the preflight requests carry no API key.
+	handler = checkOrigin(cfg.AllowedOrigins, handler)
+	slog.Info("serving MCP over HTTP", "transport", cfg.Transport, "listen", cfg.Listen, "multi_tenant", cfg.MultiTenant, "api_key", cfg.APIKey != "")
+	httpServer := &http.Server{
+		Addr:              cfg.Listen,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Stop on SIGINT and SIGTERM letting the calls in flight finish, so the
+	// deferred cleanup runs like with the stdio transport.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	err := httpServer.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
 }
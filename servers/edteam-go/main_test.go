@@ -0,0 +1,13 @@
+package main
+
+import (
+	"testing"
+
+	"go.uber.org/goleak"
+)
+
+// TestMain fails the tests that leave goroutines behind, like a tool call
+// still waiting for EDteam after it was cancelled.
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
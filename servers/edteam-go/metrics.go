@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type ToolStats struct {
+	Calls    int64
+	Errors   int64
+	Duration time.Duration
+}
+
+// Metrics counts the calls of every tool since the server started. It is
+// safe for concurrent use.
+type Metrics struct {
+	mu    sync.Mutex
+	tools map[string]*ToolStats
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{tools: make(map[string]*ToolStats)}
+}
+
+func (m *Metrics) record(tool string, duration time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.tools[tool]
+	if !ok {
+		stats = &ToolStats{}
+		m.tools[tool] = stats
+	}
+	stats.Calls++
+	stats.Duration += duration
+	if failed {
+		stats.Errors++
+	}
+}
+
+// Snapshot returns a copy of the stats by tool name.
+func (m *Metrics) Snapshot() map[string]ToolStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]ToolStats, len(m.tools))
+	for name, stats := range m.tools {
+		snapshot[name] = *stats
+	}
+
+	return snapshot
+}
+
+// recordMetrics counts the calls of every tool in metrics, nothing is
+// recorded when the telemetry is off.
+func recordMetrics(metrics *Metrics, enabled bool) ToolMiddleware {
+	return func(tool mcp.Tool, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		if !enabled {
+			return next
+		}
+
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := next(ctx, request)
+			metrics.record(tool.Name, time.Since(start), err != nil || (result != nil && result.IsError))
+
+			return result, err
+		}
+	}
+}
+
+// ServeHTTP writes the stats in the Prometheus text format, the sse and http
+// transports serve it on /metrics unless TELEMETRY is off.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	snapshot := m.Snapshot()
+	names := make([]string, 0, len(snapshot))
+	for name := range snapshot {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintln(w, "# TYPE edteam_mcp_tool_calls_total counter")
+	for _, name := range names {
+		fmt.Fprintf(w, "edteam_mcp_tool_calls_total{tool=%q} %d\n", name, snapshot[name].Calls)
+	}
+	fmt.Fprintln(w, "# TYPE edteam_mcp_tool_errors_total counter")
+	for _, name := range names {
+		fmt.Fprintf(w, "edteam_mcp_tool_errors_total{tool=%q} %d\n", name, snapshot[name].Errors)
+	}
+	fmt.Fprintln(w, "# TYPE edteam_mcp_tool_duration_seconds_sum counter")
+	for _, name := range names {
+		fmt.Fprintf(w, "edteam_mcp_tool_duration_seconds_sum{tool=%q} %g\n", name, snapshot[name].Duration.Seconds())
+	}
+}
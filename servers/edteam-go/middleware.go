@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ToolMiddleware wraps the handler of tool. It gets the tool to read its
+// name and annotations, like the limits that only apply to the tools with
+// side effects.
+type ToolMiddleware func(tool mcp.Tool, next server.ToolHandlerFunc) server.ToolHandlerFunc
+
+// applyMiddlewares wraps the handler of every tool with middlewares, the
+// first one is the outermost.
+func applyMiddlewares(tools []server.ServerTool, middlewares ...ToolMiddleware) []server.ServerTool {
+	wrapped := make([]server.ServerTool, len(tools))
+	for i, tool := range tools {
+		handler := tool.Handler
+		for j := len(middlewares) - 1; j >= 0; j-- {
+			handler = middlewares[j](tool.Tool, handler)
+		}
+		wrapped[i] = server.ServerTool{Tool: tool.Tool, Handler: handler}
+	}
+
+	return wrapped
+}
+
+// toolMiddlewares are the middlewares of every tool for cfg, in the order
+// they run. confirm asks the user to approve the side effects.
+func (srv *Server) toolMiddlewares(cfg Config, confirm ToolMiddleware) []ToolMiddleware {
+	checkRateLimit, recordRateLimit := rateLimit(srv.calls, cfg.Locale, cfg.RateLimits)
+	return []ToolMiddleware{
+		recoverPanics(cfg.Locale),
+		recordMetrics(srv.metrics, cfg.Telemetry),
+		logCalls,
+		cacheResults(cfg.ResultCacheTTL, cfg.ResultCacheTTLs),
+		checkRateLimit,
+		limitConcurrency(cfg.Locale, srv.slots),
+		withTimeout(cfg.ToolTimeout),
+		requireAccount(cfg.Locale, srv.canCall),
+		confirm,
+		recordRateLimit,
+		driftWarnings(cfg.DriftWarnings),
+	}
+}
+
+// recoverPanics turns a panic of the handler into an error result, so a bug
+// in one tool doesn't take down the server.
+func recoverPanics(locale Locale) ToolMiddleware {
+	return func(tool mcp.Tool, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					slog.Error("tool panicked", "tool", tool.Name, "panic", r, "stack", string(debug.Stack()))
+					result, err = toolErrorResult(fmt.Errorf("the tool %s failed unexpectedly", tool.Name), NewArgs(request.GetArguments()).Locale(locale)), nil
+				}
+			}()
+
+			return next(ctx, request)
+		}
+	}
+}
+
+func logCalls(tool mcp.Tool, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		result, err := next(ctx, request)
+		duration := time.Since(start)
+		switch {
+		case err != nil:
+			slog.Error("tool call failed", "tool", tool.Name, "duration", duration, "err", err)
+		case result != nil && result.IsError:
+			slog.Info("tool call returned an error", "tool", tool.Name, "duration", duration)
+		default:
+			slog.Debug("tool call", "tool", tool.Name, "duration", duration)
+		}
+
+		return result, err
+	}
+}
+
+// withTimeout bounds every call, zero disables it.
+func withTimeout(timeout time.Duration) ToolMiddleware {
+	return func(tool mcp.Tool, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		if timeout <= 0 {
+			return next
+		}
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// requireAccount rejects the calls to the tools that use the EDteam account
+// when there is no token to call them with.
+func requireAccount(locale Locale, canCall func(ctx context.Context) bool) ToolMiddleware {
+	return func(tool mcp.Tool, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		if contains(publicTools, tool.Name) {
+			return next
+		}
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !canCall(ctx) {
+				return toolErrorResult(ErrMissingToken, NewArgs(request.GetArguments()).Locale(locale)), nil
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+func driftWarnings(enabled bool) ToolMiddleware {
+	return func(tool mcp.Tool, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return withDriftWarnings(enabled, next)
+	}
+}
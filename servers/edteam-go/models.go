@@ -2,77 +2,170 @@ package main
 
 import (
 	"time"
+
+	"edteam-mcp/pkg/edteam"
+)
+
+// The models of the endpoints promoted to the edteam package.
+type (
+	Subscription           = edteam.Subscription
+	SubscriptionResponse   = edteam.SubscriptionResponse
+	CourseResponse         = edteam.CourseResponse
+	Course                 = edteam.Course
+	CoursePrice            = edteam.CoursePrice
+	Message                = edteam.Message
+	ShoppingCartResponse   = edteam.ShoppingCartResponse
+	GiftResponse           = edteam.GiftResponse
+	ProfileResponse        = edteam.ProfileResponse
+	Profile                = edteam.Profile
+	TeamMembersResponse    = edteam.TeamMembersResponse
+	SeatResponse           = edteam.SeatResponse
+	MemberProgressResponse = edteam.MemberProgressResponse
+	MemberProgress         = edteam.MemberProgress
+	ReferralResponse       = edteam.ReferralResponse
+	Referral               = edteam.Referral
+	BillingAddress         = edteam.BillingAddress
+	BillingAddressResponse = edteam.BillingAddressResponse
+	PaymentMethodsResponse = edteam.PaymentMethodsResponse
+	FAQ                    = edteam.FAQ
+	TrailerResponse        = edteam.TrailerResponse
+	Trailer                = edteam.Trailer
+	ThreadsResponse        = edteam.ThreadsResponse
+	ThreadResponse         = edteam.ThreadResponse
+	Thread                 = edteam.Thread
+	ReviewResponse         = edteam.ReviewResponse
+	Review                 = edteam.Review
+	SupportTicketResponse  = edteam.SupportTicketResponse
+	PrivacyRequestResponse = edteam.PrivacyRequestResponse
+	LiveEventsResponse     = edteam.LiveEventsResponse
+	BlogPostsResponse      = edteam.BlogPostsResponse
+	LastWatchedResponse    = edteam.LastWatchedResponse
+	LastWatched            = edteam.LastWatched
+	OwnedCoursesResponse   = edteam.OwnedCoursesResponse
+	OwnedCourse            = edteam.OwnedCourse
+	CurriculumResponse     = edteam.CurriculumResponse
+	CurriculumModule       = edteam.CurriculumModule
+	SupportTicket          = edteam.SupportTicket
 )
 
-type Subscription struct {
-	ID               int       `json:"id"`
-	SubscriptionDate time.Time `json:"subscription_date"`
-	Months           int       `json:"months"`
-	BeginsAt         time.Time `json:"begins_at"`
-	EndsAt           time.Time `json:"ends_at"`
-	State            string    `json:"state"`
-	Observations     string    `json:"observations"`
-	CreatedAt        time.Time `json:"created_at"`
-	Buyer            string    `json:"buyer"`
+// CoursesView is a list of courses as the tools return them.
+type CoursesView struct {
+	Data []CourseView `json:"data"`
 }
 
-type SubscriptionResponse struct {
-	Data []Subscription `json:"data"`
+// CourseView is a course of the catalog with the fields filled in by the
+// server. They are added to the details and to the prices, so the view keeps
+// the shape of the EDteam response.
+type CourseView struct {
+	Course       CourseDetailsView  `json:"course"`
+	CoursePrices []CoursePriceView  `json:"course_prices"`
+	Professors   []edteam.Professor `json:"professors"`
 }
 
-type Login struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+type CourseDetailsView struct {
+	edteam.CourseDetails
+	CreatedAtHuman string   `json:"created_at_human,omitempty"`
+	Classes        *int     `json:"classes,omitempty"`
+	DurationHours  *float64 `json:"duration_hours,omitempty"`
 }
 
-type LoginResponse struct {
-	Data struct {
-		Token string `json:"token"`
-	} `json:"data"`
+// CoursePriceView is a price with its ISO currency code and, when the caller
+// asks for another currency, the converted amounts.
+type CoursePriceView struct {
+	edteam.CoursePrice
+	Currency           string   `json:"currency,omitempty"`
+	ConvertedPrice     *float64 `json:"converted_price,omitempty"`
+	ConvertedBasePrice *float64 `json:"converted_base_price,omitempty"`
+	ConvertedCurrency  string   `json:"converted_currency,omitempty"`
 }
 
-type CourseResponse struct {
-	Data []struct {
-		Course struct {
-			AddressedTo     string    `json:"addressed_to"`
-			CourseType      string    `json:"course_type"`
-			CreatedAt       time.Time `json:"created_at"`
-			ID              int       `json:"id"`
-			Level           string    `json:"level"`
-			Name            string    `json:"name"`
-			OnSale          bool      `json:"on_sale"`
-			Picture         string    `json:"picture"`
-			Slug            string    `json:"slug"`
-			Subtitle        string    `json:"subtitle"`
-			VerticalPicture string    `json:"vertical_picture"`
-			Visible         bool      `json:"visible"`
-			YouLearn        string    `json:"you_learn"`
-		} `json:"course"`
-		CoursePrices []struct {
-			BasePrice  int       `json:"base_price"`
-			CreatedAt  time.Time `json:"created_at"`
-			CurrencyId int       `json:"currency_id"`
-			ID         int       `json:"id"`
-			Price      int       `json:"price"`
-		} `json:"course_prices"`
-		Professors []struct {
-			Biography   string    `json:"biography"`
-			City        string    `json:"city"`
-			CountryName string    `json:"country_name"`
-			CreatedAt   time.Time `json:"created_at"`
-			Firstname   string    `json:"firstname"`
-			ID          int       `json:"id"`
-			Lastname    string    `json:"lastname"`
-			Nickname    string    `json:"nickname"`
-			Picture     string    `json:"picture"`
-		} `json:"professors"`
-	} `json:"data"`
+// SubscriptionsView is the subscription history as the tools return it.
+type SubscriptionsView struct {
+	Data []SubscriptionView `json:"data"`
 }
 
-type ShoppingCartResponse struct {
-	Messages []struct {
-		Title   string `json:"title"`
-		Message string `json:"message"`
-		Code    string `json:"code"`
+// SubscriptionView is a subscription with its dates relative to now.
+type SubscriptionView struct {
+	edteam.Subscription
+	BeginsAtHuman string `json:"begins_at_human,omitempty"`
+	EndsAtHuman   string `json:"ends_at_human,omitempty"`
+}
+
+func newCoursesView(courses CourseResponse) CoursesView {
+	view := CoursesView{Data: make([]CourseView, len(courses.Data))}
+	for i, item := range courses.Data {
+		view.Data[i] = newCourseView(item)
 	}
+
+	return view
+}
+
+func newCourseView(item Course) CourseView {
+	var prices []CoursePriceView
+	if item.CoursePrices != nil {
+		prices = make([]CoursePriceView, len(item.CoursePrices))
+		for i, price := range item.CoursePrices {
+			prices[i] = CoursePriceView{CoursePrice: price}
+		}
+	}
+
+	return CourseView{
+		Course:       CourseDetailsView{CourseDetails: item.Course},
+		CoursePrices: prices,
+		Professors:   item.Professors,
+	}
+}
+
+func newSubscriptionsView(subscriptions SubscriptionResponse) SubscriptionsView {
+	view := SubscriptionsView{Data: make([]SubscriptionView, len(subscriptions.Data))}
+	for i, subscription := range subscriptions.Data {
+		view.Data[i] = SubscriptionView{Subscription: subscription}
+	}
+
+	return view
+}
+
+type PaymentMethod struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Brand    string `json:"brand"`
+	Number   string `json:"number,omitempty"`
+	Expires  string `json:"expires,omitempty"`
+	Default  bool   `json:"default"`
+	Provider string `json:"provider"`
+}
+
+type CoursePreview struct {
+	CourseID    int            `json:"course_id"`
+	CourseName  string         `json:"course_name"`
+	TrailerURL  string         `json:"trailer_url,omitempty"`
+	FreeClasses []PreviewClass `json:"free_classes"`
+}
+
+type PreviewClass struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// CourseDetail is a course of the catalog with its curriculum, Error is set
+// instead when it can't be found or fetched.
+type CourseDetail struct {
+	Course        *CourseView        `json:"course,omitempty"`
+	Classes       int                `json:"classes,omitempty"`
+	DurationHours float64            `json:"duration_hours,omitempty"`
+	TrailerURL    string             `json:"trailer_url,omitempty"`
+	Curriculum    []CurriculumModule `json:"curriculum,omitempty"`
+	Error         *ToolError         `json:"error,omitempty"`
+}
+
+type ContinueLearning struct {
+	CourseID       int       `json:"course_id"`
+	CourseName     string    `json:"course_name"`
+	ClassID        int       `json:"class_id"`
+	ClassName      string    `json:"class_name"`
+	Progress       float64   `json:"progress"`
+	WatchedAt      time.Time `json:"watched_at"`
+	WatchedAtHuman string    `json:"watched_at_human"`
+	URL            string    `json:"url"`
 }
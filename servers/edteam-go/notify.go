@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// The loggers of the notifications, clients can tell the events apart by
+// them.
+const (
+	LoggerWatch        = "watch"
+	LoggerNewCourse    = "new_course"
+	LoggerSubscription = "subscription"
+	LoggerDigest       = "digest"
+)
+
+// SubscriptionAlert is sent once when the active subscription is about to
+// expire and there is no renewal.
+type SubscriptionAlert struct {
+	SubscriptionID int       `json:"subscription_id"`
+	EndsAt         time.Time `json:"ends_at"`
+	EndsAtHuman    string    `json:"ends_at_human"`
+	Message        string    `json:"message"`
+	URL            string    `json:"url"`
+}
+
+// Notifier sends the noteworthy changes found by the background sync to the
+// connected clients as logging messages.
+type Notifier struct {
+	server  *server.MCPServer
+	client  *edteam.Client
+	session *Session
+	locale  Locale
+	// topics are the words that make a new course worth a notification,
+	// matched in its name and subtitle. The catalog has no categories.
+	topics []string
+	// expiringWithin is how long before the end of the subscription it is
+	// notified, zero disables it.
+	expiringWithin time.Duration
+
+	mu sync.Mutex
+	// known are the courses already in the catalog, nil until the first check.
+	known map[int]bool
+	// warned are the subscriptions already notified.
+	warned map[int]bool
+	// lastDigest is when the previous digest was sent.
+	lastDigest time.Time
+}
+
+func NewNotifier(s *server.MCPServer, client *edteam.Client, session *Session, cfg Config) *Notifier {
+	topics := make([]string, 0, len(cfg.FollowedTopics))
+	for _, topic := range cfg.FollowedTopics {
+		topics = append(topics, strings.ToLower(topic))
+	}
+
+	return &Notifier{
+		server:         s,
+		client:         client,
+		session:        session,
+		locale:         cfg.Locale,
+		topics:         topics,
+		expiringWithin: cfg.SubscriptionWarning,
+		warned:         make(map[int]bool),
+	}
+}
+
+func (n *Notifier) send(level mcp.LoggingLevel, logger string, data any) {
+	notification := mcp.NewLoggingMessageNotification(level, logger, data)
+	n.server.SendNotificationToAllClients(notification.Method, map[string]any{
+		"level":  notification.Params.Level,
+		"logger": notification.Params.Logger,
+		"data":   notification.Params.Data,
+	})
+}
+
+func (n *Notifier) Sales(alerts []SaleAlert) {
+	for _, alert := range alerts {
+		n.send(mcp.LoggingLevelNotice, LoggerWatch, alert)
+	}
+}
+
+// NewCourses notifies the courses matching the followed topics that weren't
+// in the catalog of the previous call. The first call only learns the
+// catalog.
+func (n *Notifier) NewCourses(courses CourseResponse) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	first := n.known == nil
+	if first {
+		n.known = make(map[int]bool, len(courses.Data))
+	}
+	for _, item := range courses.Data {
+		course := item.Course
+		if n.known[course.ID] {
+			continue
+		}
+		n.known[course.ID] = true
+		if first || !n.follows(course.Name+" "+course.Subtitle) {
+			continue
+		}
+		n.send(mcp.LoggingLevelInfo, LoggerNewCourse, NewCourse{
+			ID:          course.ID,
+			Name:        course.Name,
+			Level:       course.Level,
+			PublishedAt: course.CreatedAt.Time,
+			URL:         classURL(course.Slug, ""),
+		})
+	}
+}
+
+func (n *Notifier) follows(text string) bool {
+	text = strings.ToLower(text)
+	for _, topic := range n.topics {
+		if strings.Contains(text, topic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Subscription notifies the active subscription when it ends within
+// expiringWithin and no other subscription follows it. It needs the
+// credentials of the server, a multi-tenant server has no account to check.
+func (n *Notifier) Subscription(ctx context.Context, now time.Time) {
+	if n.expiringWithin <= 0 || !n.session.CanCall(ctx) {
+		return
+	}
+
+	var subscriptions SubscriptionResponse
+	err := n.session.Do(ctx, func(token string) (err error) {
+		subscriptions, err = n.client.Subscriptions.List(ctx, token)
+		return err
+	})
+	if err != nil {
+		log.Printf("failed to check the subscription: %v", err)
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	subscription, ok := activeSubscription(subscriptions, now)
+	if !ok || n.warned[subscription.ID] || subscription.EndsAt.Sub(now) > n.expiringWithin {
+		return
+	}
+	for _, next := range subscriptions.Data {
+		if !next.BeginsAt.Before(subscription.EndsAt.Time) {
+			return
+		}
+	}
+
+	n.warned[subscription.ID] = true
+	endsAtHuman := relativeTime(now, subscription.EndsAt.Time, n.locale)
+	n.send(mcp.LoggingLevelWarning, LoggerSubscription, SubscriptionAlert{
+		SubscriptionID: subscription.ID,
+		EndsAt:         subscription.EndsAt.Time,
+		EndsAtHuman:    endsAtHuman,
+		Message:        n.locale.T("subscription_expiring", endsAtHuman),
+		URL:            "https://ed.team/premium",
+	})
+}
+
+// Digest sends the courses and the blog articles published since the
+// previous digest, or in the last week for the first one.
+func (n *Notifier) Digest(ctx context.Context, catalog *Catalog, now time.Time) {
+	courses, err := catalog.Courses(ctx)
+	if err != nil {
+		log.Printf("failed to build the digest: %v", err)
+		return
+	}
+	posts, err := n.client.Content.BlogPosts(ctx)
+	if err != nil {
+		log.Printf("failed to build the digest: %v", err)
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var since *time.Time
+	if !n.lastDigest.IsZero() {
+		since = &n.lastDigest
+	}
+	digest := whatsNew(courses, posts, since, nil, now, n.locale)
+	n.lastDigest = now
+	n.send(mcp.LoggingLevelInfo, LoggerDigest, digest)
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"edteam-mcp/pkg/edteam"
+)
+
+// maxLastPageProbes bounds the requests used to find the last page when the
+// requested one is past the end of the catalog.
+const maxLastPageProbes = 8
+
+// Pagination is included in every page of courses so agents know whether to
+// keep paging and how.
+type Pagination struct {
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	LastPage   int    `json:"last_page,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+type cursor struct {
+	Page  int `json:"p"`
+	Limit int `json:"l"`
+}
+
+func encodeCursor(page, limit int) string {
+	raw, _ := json.Marshal(cursor{Page: page, Limit: limit})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(value string) (int, int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed cursor")
+	}
+
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.Page < 1 || c.Page > MaxSafeInt || c.Limit < 1 {
+		return 0, 0, fmt.Errorf("malformed cursor")
+	}
+
+	return c.Page, c.Limit, nil
+}
+
+// Cursor returns the page and limit stored in the cursor argument, or page
+// and limit when there is no cursor.
+func (a *Args) Cursor(page, limit, maxLimit int) (int, int) {
+	value := a.String("cursor", "")
+	if value == "" {
+		return page, limit
+	}
+
+	cursorPage, cursorLimit, err := decodeCursor(value)
+	if err != nil {
+		a.fail("cursor", "%v, use the next_cursor of a previous page", err)
+		return page, limit
+	}
+	if cursorLimit > maxLimit {
+		a.fail("cursor", "has a limit of %d but the maximum is %d, start again from a page with a lower limit", cursorLimit, maxLimit)
+		return page, limit
+	}
+
+	return cursorPage, cursorLimit
+}
+
+// paginate describes the page of courses. When the page is empty and past the
+// end of the catalog it looks for the last page with a binary search.
+func paginate(ctx context.Context, api edteam.CoursesAPI, page, limit, count int, locale Locale) Pagination {
+	p := Pagination{Page: page, Limit: limit}
+
+	switch {
+	case count >= limit:
+		p.HasMore = true
+		p.NextCursor = encodeCursor(page+1, limit)
+	case count > 0:
+		p.LastPage = page
+		p.Message = locale.T("last_page_reached")
+	case page == 1:
+		p.Message = locale.T("no_courses")
+	default:
+		p.LastPage = findLastPage(ctx, api, page, limit)
+		if p.LastPage > 0 {
+			p.Message = locale.T("past_last_page", p.LastPage)
+		} else {
+			p.Message = locale.T("past_end")
+		}
+	}
+
+	return p
+}
+
+// localPagination describes a page of results filtered in memory, where the
+// total is known.
+func localPagination(page, limit, total int, locale Locale) Pagination {
+	p := Pagination{Page: page, Limit: limit}
+	lastPage := (total + limit - 1) / limit
+
+	switch {
+	case total == 0:
+		p.Message = locale.T("no_courses")
+	case page < lastPage:
+		p.HasMore = true
+		p.NextCursor = encodeCursor(page+1, limit)
+	case page == lastPage:
+		p.LastPage = lastPage
+		p.Message = locale.T("last_page_reached")
+	default:
+		p.LastPage = lastPage
+		p.Message = locale.T("past_last_page", lastPage)
+	}
+
+	return p
+}
+
+func findLastPage(ctx context.Context, api edteam.CoursesAPI, empty, limit int) int {
+	low, high := 0, empty
+	for probes := 0; high-low > 1 && probes < maxLastPageProbes; probes++ {
+		mid := low + (high-low)/2
+		courses, err := api.List(ctx, uint(mid), uint(limit))
+		if err != nil {
+			log.Printf("failed to look for the last page: %v", err)
+			return 0
+		}
+		if len(courses.Data) > 0 {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	if high-low > 1 {
+		return 0
+	}
+
+	return low
+}
+
+// renderPage renders a page of items with its pagination: as a field of the
+// JSON object, as a last line in jsonl and as a footer in markdown.
+func renderPage(format string, items any, p Pagination, markdown func() string, locale Locale) (string, error) {
+	switch format {
+	case FormatMarkdown:
+		return markdown() + "\n" + p.summary(locale), nil
+	case FormatJSONLines:
+		lines, err := jsonLines(items)
+		if err != nil {
+			return "", err
+		}
+		last, err := marshalString(struct {
+			Pagination Pagination `json:"pagination"`
+		}{p})
+		if err != nil {
+			return "", err
+		}
+		return lines + last + "\n", nil
+	}
+
+	return marshalString(struct {
+		Data       any        `json:"data"`
+		Pagination Pagination `json:"pagination"`
+	}{items, p})
+}
+
+func (p Pagination) summary(locale Locale) string {
+	if p.HasMore {
+		return locale.T("more_results", p.Page, p.NextCursor)
+	}
+
+	return p.Message
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"math/rand/v2"
+	"sync"
+	"testing"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+	"edteam-mcp/pkg/edteam/edteammock"
+
+	"pgregory.net/rapid"
+)
+
+// generatedCatalog draws a catalog with unique IDs. The names and the
+// prices repeat often, so the sorts have ties.
+func generatedCatalog(t *rapid.T) CourseResponse {
+	ids := rapid.SliceOfNDistinct(rapid.IntRange(1, 100_000), 0, 300, rapid.ID[int]).Draw(t, "ids")
+	courses := CourseResponse{Data: make([]Course, len(ids))}
+	for i, id := range ids {
+		courses.Data[i] = Course{
+			Course: edteam.CourseDetails{
+				ID:   id,
+				Name: rapid.SampledFrom([]string{"Go", "go", "Rust", "SQL"}).Draw(t, "name"),
+			},
+			CoursePrices: []CoursePrice{{CurrencyId: 1, Price: rapid.IntRange(0, 3).Draw(t, "price")}},
+		}
+	}
+
+	return courses
+}
+
+// catalogAPI serves courses by page like cache-edql.
+func catalogAPI(courses CourseResponse) *edteammock.CoursesAPIMock {
+	return &edteammock.CoursesAPIMock{
+		ListFunc: func(ctx context.Context, page, limit uint, opts ...edteam.CallOption) (edteam.CourseResponse, error) {
+			return cloneCourses(CourseResponse{Data: pageOf(courses.Data, int(page), int(limit))}), nil
+		},
+	}
+}
+
+// checkEveryCourseOnce fails unless walked has every course of courses
+// exactly once.
+func checkEveryCourseOnce(t *rapid.T, courses CourseResponse, walked []int) {
+	seen := make(map[int]int, len(walked))
+	for _, id := range walked {
+		seen[id]++
+	}
+	for _, course := range courses.Data {
+		if n := seen[course.Course.ID]; n != 1 {
+			t.Fatalf("course %d was walked %d times", course.Course.ID, n)
+		}
+	}
+	if len(walked) != len(courses.Data) {
+		t.Fatalf("walked %d courses, the catalog has %d", len(walked), len(courses.Data))
+	}
+}
+
+// nextPage follows the cursor of p like a client, through the binder.
+func nextPage(t *rapid.T, p Pagination, maxLimit int) (int, int) {
+	args := NewArgs(map[string]any{"cursor": p.NextCursor})
+	page, limit := args.Cursor(1, 1, maxLimit)
+	if err := args.Err(); err != nil {
+		t.Fatalf("the next_cursor %q of page %d is rejected: %v", p.NextCursor, p.Page, err)
+	}
+
+	return page, limit
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		page := rapid.IntRange(1, MaxSafeInt).Draw(t, "page")
+		limit := rapid.IntRange(1, 100).Draw(t, "limit")
+
+		args := NewArgs(map[string]any{"cursor": encodeCursor(page, limit)})
+		gotPage, gotLimit := args.Cursor(1, 10, 100)
+		if err := args.Err(); err != nil || gotPage != page || gotLimit != limit {
+			t.Fatalf("Cursor() = %d, %d, %v, want %d, %d", gotPage, gotLimit, err, page, limit)
+		}
+	})
+}
+
+func TestDecodeCursorRejectsGarbage(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		value := rapid.String().Draw(t, "cursor")
+		page, limit, err := decodeCursor(value)
+		if err == nil && (page < 1 || limit < 1) {
+			t.Fatalf("decodeCursor(%q) = %d, %d, want an error", value, page, limit)
+		}
+	})
+}
+
+func TestDecodeCursorRejectsHugePages(t *testing.T) {
+	value := base64.RawURLEncoding.EncodeToString([]byte(`{"p":4611686018427387905,"l":2}`))
+	if page, limit, err := decodeCursor(value); err == nil {
+		t.Fatalf("decodeCursor(%q) = %d, %d, want an error", value, page, limit)
+	}
+}
+
+func TestPageOfPastTheEnd(t *testing.T) {
+	courses := make([]Course, 10)
+	tests := []struct {
+		page, limit int
+		want        int
+	}{
+		{page: 1, limit: 3, want: 3},
+		{page: 4, limit: 3, want: 1},
+		{page: 5, limit: 3, want: 0},
+		{page: 4611686018427387905, limit: 2, want: 0},
+		{page: MaxSafeInt, limit: 100, want: 0},
+	}
+	for _, tt := range tests {
+		if got := len(pageOf(courses, tt.page, tt.limit)); got != tt.want {
+			t.Errorf("pageOf(page %d, limit %d) has %d courses, want %d", tt.page, tt.limit, got, tt.want)
+		}
+	}
+}
+
+// TestWalkCatalogByCursor follows next_cursor from the first page of the
+// remote catalog: every course comes once, whatever the page size.
+func TestWalkCatalogByCursor(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		courses := generatedCatalog(t)
+		api := catalogAPI(courses)
+		page, limit := 1, rapid.IntRange(1, 60).Draw(t, "limit")
+
+		var walked []int
+		for range len(courses.Data) + 2 {
+			got, _ := api.List(context.Background(), uint(page), uint(limit))
+			for _, course := range got.Data {
+				walked = append(walked, course.Course.ID)
+			}
+			p := paginate(context.Background(), api, page, limit, len(got.Data), LocaleEN)
+			if !p.HasMore {
+				break
+			}
+			page, limit = nextPage(t, p, 60)
+		}
+
+		checkEveryCourseOnce(t, courses, walked)
+	})
+}
+
+// TestWalkSortedCatalogDuringRefresh walks a sorted catalog, paginated from
+// the cached copy, while it is fetched again and again. EDteam returns it in
+// another order every time, the pages must not depend on it.
+func TestWalkSortedCatalogDuringRefresh(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		courses := generatedCatalog(t)
+		sortBy := rapid.SampledFrom(sortOptions).Draw(t, "sort")
+		limit := rapid.IntRange(1, 60).Draw(t, "limit")
+
+		var mu sync.Mutex
+		api := &edteammock.CoursesAPIMock{
+			ListFunc: func(ctx context.Context, page, limit uint, opts ...edteam.CallOption) (edteam.CourseResponse, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				if page == 1 {
+					rand.Shuffle(len(courses.Data), func(i, j int) {
+						courses.Data[i], courses.Data[j] = courses.Data[j], courses.Data[i]
+					})
+				}
+				return cloneCourses(CourseResponse{Data: pageOf(courses.Data, int(page), int(limit))}), nil
+			},
+		}
+		catalog := NewCatalog(api, time.Hour, 50, LocaleEN)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		refreshed := make(chan struct{})
+		go func() {
+			defer close(refreshed)
+			for ctx.Err() == nil {
+				catalog.Refresh(ctx)
+			}
+		}()
+		defer func() { <-refreshed }()
+		defer cancel()
+
+		page := 1
+		var walked []int
+		for range len(courses.Data) + 2 {
+			// Every page follows a refresh, besides the ones of the goroutine.
+			if _, _, _, err := catalog.Refresh(context.Background()); err != nil {
+				t.Fatalf("Refresh() error = %v", err)
+			}
+			cached, err := catalog.Courses(context.Background())
+			if err != nil {
+				t.Fatalf("Courses() error = %v", err)
+			}
+			view := newCoursesView(cached)
+			if err := applyCurrencies(&view, defaultCurrencyCodes, Rates{}, ""); err != nil {
+				t.Fatal(err)
+			}
+			if err := sortCourses(&view, sortBy, Rates{}); err != nil {
+				t.Fatal(err)
+			}
+			for _, course := range pageOf(view.Data, page, limit) {
+				walked = append(walked, course.Course.ID)
+			}
+			p := localPagination(page, limit, len(cached.Data), LocaleEN)
+			if !p.HasMore {
+				break
+			}
+			page, limit = nextPage(t, p, 60)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		checkEveryCourseOnce(t, courses, walked)
+	})
+}
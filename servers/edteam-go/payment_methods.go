@@ -0,0 +1,25 @@
+package main
+
+// maskPaymentMethods keeps only what identifies a saved method to the user.
+// Only the last four digits are decoded from the response, and they are
+// returned masked so the full number never reaches the assistant.
+func maskPaymentMethods(methods PaymentMethodsResponse) []PaymentMethod {
+	masked := make([]PaymentMethod, 0, len(methods.Data))
+	for _, method := range methods.Data {
+		number := ""
+		if last4 := method.Last4; len(last4) >= 4 {
+			number = "**** " + last4[len(last4)-4:]
+		}
+		masked = append(masked, PaymentMethod{
+			ID:       method.ID,
+			Type:     method.Type,
+			Brand:    method.Brand,
+			Number:   number,
+			Expires:  method.Expires,
+			Default:  method.Default,
+			Provider: method.Provider,
+		})
+	}
+
+	return masked
+}
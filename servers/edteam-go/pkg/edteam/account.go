@@ -0,0 +1,162 @@
+package edteam
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	urlProfile        = "https://api.ed.team/api/v1/users/me"
+	urlReferrals      = "https://api.ed.team/api/v1/users/me/referrals"
+	urlLastWatched    = "https://api.ed.team/api/v1/users/me/last-watched-class"
+	urlOwnedCourses   = "https://api.ed.team/api/v1/users/me/courses"
+	urlDataExport     = "https://api.ed.team/api/v1/users/me/data-export"
+	urlDeletion       = "https://api.ed.team/api/v1/users/me/deletion-request"
+	urlSupportTickets = "https://api.ed.team/api/v1/support/tickets"
+)
+
+// The categories of the support tickets.
+const (
+	TicketBilling     = "billing"
+	TicketAccess      = "access"
+	TicketTechnical   = "technical"
+	TicketCertificate = "certificate"
+	TicketOther       = "other"
+)
+
+// AccountService reads and changes the account of the token: the profile,
+// the progress, the privacy requests and the help requests.
+type AccountService struct {
+	client *Client
+}
+
+// Profile returns the name and the email of the account.
+func (s *AccountService) Profile(ctx context.Context, token string, opts ...CallOption) (ProfileResponse, error) {
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodGet, urlProfile, token, nil, opts...)
+	if err != nil {
+		return ProfileResponse{}, err
+	}
+	if statusCode != http.StatusOK {
+		return ProfileResponse{}, NewStatusError(statusCode, responseBody)
+	}
+	// Parse the response
+	var profile ProfileResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &profile)
+	if err != nil {
+		return ProfileResponse{}, err
+	}
+
+	return profile, nil
+}
+
+// Referral returns the referral link of the account and what it earned.
+func (s *AccountService) Referral(ctx context.Context, token string, opts ...CallOption) (ReferralResponse, error) {
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodGet, urlReferrals, token, nil, opts...)
+	if err != nil {
+		return ReferralResponse{}, err
+	}
+	if statusCode != http.StatusOK {
+		return ReferralResponse{}, unconfirmedStatusError(http.MethodGet, urlReferrals, statusCode, responseBody)
+	}
+	// Parse the response
+	var referral ReferralResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &referral)
+	if err != nil {
+		return ReferralResponse{}, err
+	}
+
+	return referral, nil
+}
+
+// LastWatched returns the last class watched in the account. It returns
+// false when no class was watched yet, EDteam answers 204 then.
+func (s *AccountService) LastWatched(ctx context.Context, token string, opts ...CallOption) (LastWatchedResponse, bool, error) {
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodGet, urlLastWatched, token, nil, opts...)
+	if err != nil {
+		return LastWatchedResponse{}, false, err
+	}
+	if statusCode == http.StatusNoContent {
+		return LastWatchedResponse{}, false, nil
+	}
+	if statusCode != http.StatusOK {
+		return LastWatchedResponse{}, false, unconfirmedStatusError(http.MethodGet, urlLastWatched, statusCode, responseBody)
+	}
+	// Parse the response
+	var lastWatched LastWatchedResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &lastWatched)
+	if err != nil {
+		return LastWatchedResponse{}, false, err
+	}
+
+	return lastWatched, true, nil
+}
+
+// OwnedCourses returns the courses bought individually in the account, the
+// ones watched through a subscription aren't part of it.
+func (s *AccountService) OwnedCourses(ctx context.Context, token string, opts ...CallOption) (OwnedCoursesResponse, error) {
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodGet, urlOwnedCourses, token, nil, opts...)
+	if err != nil {
+		return OwnedCoursesResponse{}, err
+	}
+	if statusCode != http.StatusOK {
+		return OwnedCoursesResponse{}, unconfirmedStatusError(http.MethodGet, urlOwnedCourses, statusCode, responseBody)
+	}
+	// Parse the response
+	var owned OwnedCoursesResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &owned)
+	if err != nil {
+		return OwnedCoursesResponse{}, err
+	}
+
+	return owned, nil
+}
+
+// RequestDataExport asks EDteam to prepare a copy of the account data, a
+// download link is sent to the account email when it is ready.
+func (s *AccountService) RequestDataExport(ctx context.Context, token string, opts ...CallOption) (PrivacyRequestResponse, error) {
+	return s.privacyRequest(ctx, token, urlDataExport, opts)
+}
+
+// RequestDeletion asks EDteam to delete the account. EDteam confirms by
+// email before the account is deleted.
+func (s *AccountService) RequestDeletion(ctx context.Context, token string, opts ...CallOption) (PrivacyRequestResponse, error) {
+	return s.privacyRequest(ctx, token, urlDeletion, opts)
+}
+
+func (s *AccountService) privacyRequest(ctx context.Context, token, urlRequest string, opts []CallOption) (PrivacyRequestResponse, error) {
+	statusCode, responseBody, err := s.client.Send(ctx, false, http.MethodPost, urlRequest, token, nil, opts...)
+	if err != nil {
+		return PrivacyRequestResponse{}, err
+	}
+	if statusCode != http.StatusAccepted && statusCode != http.StatusCreated {
+		return PrivacyRequestResponse{}, unconfirmedStatusError(http.MethodPost, urlRequest, statusCode, responseBody)
+	}
+	// Parse the response
+	var privacy PrivacyRequestResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &privacy)
+	if err != nil {
+		return PrivacyRequestResponse{}, err
+	}
+
+	return privacy, nil
+}
+
+// CreateSupportTicket files a help request with EDteam support. It is not
+// retried, a retry could open the ticket twice.
+func (s *AccountService) CreateSupportTicket(ctx context.Context, token string, ticket SupportTicket, opts ...CallOption) (SupportTicketResponse, error) {
+	statusCode, responseBody, err := s.client.Send(ctx, false, http.MethodPost, urlSupportTickets, token, ticket, opts...)
+	if err != nil {
+		return SupportTicketResponse{}, err
+	}
+	if statusCode != http.StatusCreated {
+		return SupportTicketResponse{}, unconfirmedStatusError(http.MethodPost, urlSupportTickets, statusCode, responseBody)
+	}
+	// Parse the response
+	var created SupportTicketResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &created)
+	if err != nil {
+		return SupportTicketResponse{}, err
+	}
+
+	return created, nil
+}
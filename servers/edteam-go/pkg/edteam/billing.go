@@ -0,0 +1,101 @@
+package edteam
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	urlBillingAddress = "https://billing-v2.ed.team/v2/private/billing-address"
+	urlPaymentMethods = "https://billing-v2.ed.team/v2/private/payment-methods"
+	urlGifts          = "https://billing-v2.ed.team/v2/private/gifts"
+)
+
+// BillingService reads the billing details of the account of the token and
+// buys courses as gifts.
+type BillingService struct {
+	client *Client
+}
+
+// Address returns the details printed in the invoices.
+func (s *BillingService) Address(ctx context.Context, token string, opts ...CallOption) (BillingAddressResponse, error) {
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodGet, urlBillingAddress, token, nil, opts...)
+	if err != nil {
+		return BillingAddressResponse{}, err
+	}
+	if statusCode != http.StatusOK {
+		return BillingAddressResponse{}, unconfirmedStatusError(http.MethodGet, urlBillingAddress, statusCode, responseBody)
+	}
+	// Parse the response
+	var address BillingAddressResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &address)
+	if err != nil {
+		return BillingAddressResponse{}, err
+	}
+
+	return address, nil
+}
+
+// UpdateAddress replaces the billing address. PUT replaces the whole
+// resource, so retrying it is safe.
+func (s *BillingService) UpdateAddress(ctx context.Context, token string, address BillingAddress, opts ...CallOption) (BillingAddressResponse, error) {
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodPut, urlBillingAddress, token, address, opts...)
+	if err != nil {
+		return BillingAddressResponse{}, err
+	}
+	if statusCode != http.StatusOK {
+		return BillingAddressResponse{}, unconfirmedStatusError(http.MethodPut, urlBillingAddress, statusCode, responseBody)
+	}
+	// Parse the response
+	var updated BillingAddressResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &updated)
+	if err != nil {
+		return BillingAddressResponse{}, err
+	}
+
+	return updated, nil
+}
+
+// PaymentMethods returns the payment methods saved in the account.
+func (s *BillingService) PaymentMethods(ctx context.Context, token string, opts ...CallOption) (PaymentMethodsResponse, error) {
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodGet, urlPaymentMethods, token, nil, opts...)
+	if err != nil {
+		return PaymentMethodsResponse{}, err
+	}
+	if statusCode != http.StatusOK {
+		return PaymentMethodsResponse{}, unconfirmedStatusError(http.MethodGet, urlPaymentMethods, statusCode, responseBody)
+	}
+	// Parse the response
+	var methods PaymentMethodsResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &methods)
+	if err != nil {
+		return PaymentMethodsResponse{}, err
+	}
+
+	return methods, nil
+}
+
+// Gift buys a course for email, charging the account. It isn't idempotent:
+// when it fails after the request could have reached EDteam it returns an
+// *AmbiguousError.
+func (s *BillingService) Gift(ctx context.Context, token string, courseID int, email string, opts ...CallOption) (GiftResponse, error) {
+	gift := struct {
+		CourseID       int    `json:"course_id"`
+		RecipientEmail string `json:"recipient_email"`
+	}{courseID, email}
+	statusCode, responseBody, err := s.client.Send(ctx, false, http.MethodPost, urlGifts, token, gift, opts...)
+	if err != nil {
+		return GiftResponse{}, err
+	}
+	if statusCode != http.StatusCreated {
+		return GiftResponse{}, unconfirmedStatusError(http.MethodPost, urlGifts, statusCode, responseBody)
+	}
+	// Parse the response
+	var response GiftResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &response)
+	if err != nil {
+		return GiftResponse{}, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,102 @@
+package edteam
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const urlBusinessMembers = "https://api.ed.team/api/v1/business/members"
+
+// BusinessService manages the team of the business plan administered by
+// the account of the token.
+type BusinessService struct {
+	client *Client
+}
+
+// Members returns the members of the team with their seats.
+func (s *BusinessService) Members(ctx context.Context, token string, opts ...CallOption) (TeamMembersResponse, error) {
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodGet, urlBusinessMembers, token, nil, opts...)
+	if err != nil {
+		return TeamMembersResponse{}, err
+	}
+	if statusCode != http.StatusOK {
+		return TeamMembersResponse{}, unconfirmedStatusError(http.MethodGet, urlBusinessMembers, statusCode, responseBody)
+	}
+	// Parse the response
+	var members TeamMembersResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &members)
+	if err != nil {
+		return TeamMembersResponse{}, err
+	}
+
+	return members, nil
+}
+
+// AssignSeat gives the member a seat in the course.
+func (s *BusinessService) AssignSeat(ctx context.Context, token string, memberID, courseID int, opts ...CallOption) (SeatResponse, error) {
+	urlSeats := fmt.Sprintf("%s/%d/seats", urlBusinessMembers, memberID)
+	seat := struct {
+		CourseID int `json:"course_id"`
+	}{courseID}
+	statusCode, responseBody, err := s.client.Send(ctx, false, http.MethodPost, urlSeats, token, seat, opts...)
+	if err != nil {
+		return SeatResponse{}, err
+	}
+	if statusCode != http.StatusCreated && statusCode != http.StatusOK {
+		return SeatResponse{}, NewStatusError(statusCode, responseBody)
+	}
+	// Parse the response
+	var response SeatResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &response)
+	if err != nil {
+		return SeatResponse{}, err
+	}
+
+	return response, nil
+}
+
+// RevokeSeat takes the course seat back from the member. Revoking is
+// idempotent, so it is retried like a read.
+func (s *BusinessService) RevokeSeat(ctx context.Context, token string, memberID, courseID int, opts ...CallOption) (SeatResponse, error) {
+	urlSeat := fmt.Sprintf("%s/%d/seats/%d", urlBusinessMembers, memberID, courseID)
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodDelete, urlSeat, token, nil, opts...)
+	if err != nil {
+		return SeatResponse{}, err
+	}
+	if statusCode == http.StatusNoContent {
+		return SeatResponse{}, nil
+	}
+	if statusCode != http.StatusOK {
+		return SeatResponse{}, NewStatusError(statusCode, responseBody)
+	}
+	// Parse the response
+	var seat SeatResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &seat)
+	if err != nil {
+		return SeatResponse{}, err
+	}
+
+	return seat, nil
+}
+
+// MemberProgress returns the progress of a member in each of the courses
+// they have a seat in.
+func (s *BusinessService) MemberProgress(ctx context.Context, token string, memberID int, opts ...CallOption) (MemberProgressResponse, error) {
+	urlProgress := fmt.Sprintf("%s/%d/progress", urlBusinessMembers, memberID)
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodGet, urlProgress, token, nil, opts...)
+	if err != nil {
+		return MemberProgressResponse{}, err
+	}
+	if statusCode != http.StatusOK {
+		return MemberProgressResponse{}, NewStatusError(statusCode, responseBody)
+	}
+	// Parse the response
+	var progress MemberProgressResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &progress)
+	if err != nil {
+		return MemberProgressResponse{}, err
+	}
+
+	return progress, nil
+}
@@ -0,0 +1,35 @@
+package edteam
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const urlShoppingCart = "https://billing-v2.ed.team/v2/private/shopping-carts"
+
+// CartService changes the shopping cart of the account of the token.
+type CartService struct {
+	client *Client
+}
+
+// Add adds a course to the cart. It isn't idempotent: when it fails after
+// the request could have reached EDteam it returns an *AmbiguousError.
+func (s *CartService) Add(ctx context.Context, token string, courseID int, opts ...CallOption) (ShoppingCartResponse, error) {
+	body := []byte(fmt.Sprintf(`{"course_id":%d}`, courseID))
+	statusCode, responseBody, err := s.client.Send(ctx, false, http.MethodPost, urlShoppingCart, token, body, opts...)
+	if err != nil {
+		return ShoppingCartResponse{}, err
+	}
+	if statusCode != http.StatusCreated {
+		return ShoppingCartResponse{}, NewStatusError(statusCode, responseBody)
+	}
+	// Parse the response
+	var shoppingCart ShoppingCartResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &shoppingCart)
+	if err != nil {
+		return ShoppingCartResponse{}, err
+	}
+
+	return shoppingCart, nil
+}
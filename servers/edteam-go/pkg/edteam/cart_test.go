@@ -0,0 +1,46 @@
+package edteam_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"edteam-mcp/pkg/edteam"
+	"edteam-mcp/testsupport"
+)
+
+func TestCartAdd(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	cart, err := client.Cart.Add(context.Background(), testsupport.Token, 101)
+	if err != nil {
+		t.Fatalf("Cart.Add() error = %v", err)
+	}
+	if len(cart.Messages) != 1 || cart.Messages[0].Code != "S001" {
+		t.Errorf("Cart.Add() = %+v, want the message of the fixture", cart)
+	}
+	if got := string(fake.Requests()[0].Body); got != `{"course_id":101}` {
+		t.Errorf("Cart.Add() sent %s, want the course id", got)
+	}
+}
+
+func TestCartAddConnectionLost(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.Handle(http.MethodPost, "/v2/private/shopping-carts", func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	})
+
+	// The request reached EDteam, it may have added the course.
+	_, err := client.Cart.Add(context.Background(), testsupport.Token, 101)
+	var ambiguousErr *edteam.AmbiguousError
+	if !errors.As(err, &ambiguousErr) {
+		t.Fatalf("Cart.Add() error = %v, want an *AmbiguousError", err)
+	}
+	if edteam.Temporary(err) {
+		t.Error("Temporary() = true, want an ambiguous error never retried")
+	}
+	if got := len(fake.Requests()); got != 1 {
+		t.Errorf("Cart.Add() sent %d requests, want 1", got)
+	}
+}
@@ -0,0 +1,412 @@
+package edteam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultPageSize is the number of courses per request of StreamCourses.
+const DefaultPageSize = 50
+
+// DefaultMaxResponseSize bounds the responses when WithMaxResponseSize isn't
+// given.
+const DefaultMaxResponseSize = 32 << 20
+
+// Client calls the EDteam API. Its services group the endpoints by what
+// they work with.
+type Client struct {
+	httpClient *http.Client
+	baseURL    *url.URL
+	userAgent  string
+	logger     *slog.Logger
+	// maxResponseSize bounds the bodies read, zero doesn't.
+	maxResponseSize int64
+
+	// Retry is the policy of the transient failures.
+	Retry RetryPolicy
+	// OnDecode, when set, is called with every body decoded by the client
+	// and the value it was decoded into, to inspect the responses, like the
+	// MCP server does to find the fields EDteam added or removed.
+	OnDecode func(ctx context.Context, body []byte, v any)
+
+	// The services are interfaces, a test can replace them with the mocks
+	// of the edteammock package.
+	Courses       CoursesAPI
+	Subscriptions SubscriptionsAPI
+	Cart          CartAPI
+	Account       AccountAPI
+	Billing       BillingAPI
+	Business      BusinessAPI
+	Community     CommunityAPI
+	Content       ContentAPI
+}
+
+// New returns a client configured by opts, without them it calls EDteam with
+// http.DefaultClient and DefaultRetryPolicy.
+func New(opts ...Option) (*Client, error) {
+	c := &Client{
+		httpClient: http.DefaultClient,
+		userAgent:  DefaultUserAgent,
+		Retry:      DefaultRetryPolicy,
+
+		maxResponseSize: DefaultMaxResponseSize,
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	c.Courses = &CoursesService{client: c}
+	c.Subscriptions = &SubscriptionsService{client: c}
+	c.Cart = &CartService{client: c}
+	c.Account = &AccountService{client: c}
+	c.Billing = &BillingService{client: c}
+	c.Business = &BusinessService{client: c}
+	c.Community = &CommunityService{client: c}
+	c.Content = &ContentService{client: c}
+
+	return c, nil
+}
+
+func (c *Client) log() *slog.Logger {
+	if c.logger == nil {
+		return slog.Default()
+	}
+
+	return c.logger
+}
+
+type languageKey struct{}
+
+type skipInspectionKey struct{}
+
+// WithoutInspection returns a copy of ctx whose responses aren't passed to
+// OnDecode, so SendDecode can decode them without reading them whole. It is
+// meant for the requests that repeat one already inspected, like the pages
+// after the first one of the catalog.
+func WithoutInspection(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipInspectionKey{}, true)
+}
+
+func skipInspection(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipInspectionKey{}).(bool)
+	return skip
+}
+
+// WithLanguage returns a copy of ctx whose requests ask EDteam to answer in
+// language, like es or en.
+func WithLanguage(ctx context.Context, language string) context.Context {
+	return context.WithValue(ctx, languageKey{}, language)
+}
+
+// Send sends a request to url with data as the JSON body, or as is when it
+// is a []byte, retrying the transient failures. opts change the headers of
+// this request only. It is the way to call the
+// endpoints that have no service yet. A body other than JSON is returned
+// with a *NonJSONError.
+func (c *Client) Send(ctx context.Context, idempotent bool, method, url, token string, data any, opts ...CallOption) (int, []byte, error) {
+	body, err := encodeBody(data)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer body.release()
+
+	return c.retry(ctx, idempotent, method, url, func() (int, []byte, error) {
+		return c.send(ctx, method, url, token, body, opts)
+	})
+}
+
+// SendDecode sends a request like Send and decodes a successful response
+// into v straight from the connection, so the body of a large page of the
+// catalog is never held in memory next to the value. The body is returned
+// only when the status isn't 200, for NewStatusError. The responses OnDecode
+// inspects are still read whole, see WithoutInspection.
+func (c *Client) SendDecode(ctx context.Context, idempotent bool, method, url, token string, data, v any, opts ...CallOption) (int, []byte, error) {
+	body, err := encodeBody(data)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer body.release()
+
+	return c.retry(ctx, idempotent, method, url, func() (int, []byte, error) {
+		return c.sendDecode(ctx, method, url, token, body, v, opts)
+	})
+}
+
+// retry calls send until it succeeds or the policy of method gives up.
+func (c *Client) retry(ctx context.Context, idempotent bool, method, url string, send func() (int, []byte, error)) (int, []byte, error) {
+	var statusCode int
+	var body []byte
+	var err error
+	policy := c.Retry.forMethod(method)
+	for attempt := 1; ; attempt++ {
+		statusCode, body, err = send()
+		if attempt >= policy.MaxAttempts || !policy.shouldRetry(statusCode, err, idempotent) {
+			break
+		}
+
+		c.log().DebugContext(ctx, "retrying the request to EDteam", "method", method, "url", url, "attempt", attempt, "status", statusCode, "error", err)
+		if err := policy.wait(ctx, attempt); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	if err != nil && !idempotent && !notSent(err) {
+		return statusCode, body, &AmbiguousError{Err: err}
+	}
+
+	return statusCode, body, err
+}
+
+func (c *Client) send(ctx context.Context, method, url, token string, body *requestBody, opts []CallOption) (int, []byte, error) {
+	resp, err := c.do(ctx, method, url, token, body, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer c.close(ctx, resp)
+
+	return c.read(resp)
+}
+
+func (c *Client) sendDecode(ctx context.Context, method, url, token string, body *requestBody, v any, opts []CallOption) (int, []byte, error) {
+	resp, err := c.do(ctx, method, url, token, body, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer c.close(ctx, resp)
+
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	inspect := c.OnDecode != nil && !skipInspection(ctx)
+	if resp.StatusCode != http.StatusOK || mediaType != "application/json" || inspect {
+		statusCode, body, err := c.read(resp)
+		if err != nil || statusCode != http.StatusOK {
+			return statusCode, body, err
+		}
+
+		return statusCode, nil, c.Decode(ctx, statusCode, body, v)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return resp.StatusCode, nil, newNonJSONError(resp.StatusCode, contentType, nil)
+		}
+		return resp.StatusCode, nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	// Read what is left, like the last newline, so the connection is reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil, nil
+}
+
+// do sends a single request, the caller closes the body of the response.
+func (c *Client) do(ctx context.Context, method, url, token string, body *requestBody, opts []CallOption) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.resolve(url), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Body = body.reader()
+		req.GetBody = func() (io.ReadCloser, error) { return body.reader(), nil }
+		req.ContentLength = int64(body.buf.Len())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if language, ok := ctx.Value(languageKey{}).(string); ok && language != "" {
+		req.Header.Set("Accept-Language", language)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+	for _, opt := range opts {
+		opt(req.Header)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	if c.maxResponseSize > 0 {
+		resp.Body = &limitedBody{
+			reader:     io.LimitReader(resp.Body, c.maxResponseSize+1),
+			Closer:     resp.Body,
+			statusCode: resp.StatusCode,
+			limit:      c.maxResponseSize,
+		}
+	}
+
+	return resp, nil
+}
+
+// limitedBody fails the reads past limit instead of cutting the body there,
+// a truncated response would be decoded as a smaller one.
+type limitedBody struct {
+	reader io.Reader
+	io.Closer
+	statusCode int
+	limit      int64
+	read       int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.reader.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		return n - int(b.read-b.limit), &ResponseTooLargeError{StatusCode: b.statusCode, Limit: b.limit}
+	}
+
+	return n, err
+}
+
+func (c *Client) close(ctx context.Context, resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		c.log().WarnContext(ctx, "failed to close the response body", "error", err)
+	}
+}
+
+// maxPooledBuffer keeps the buffers grown by a large response, like a page
+// of hundreds of courses, out of the pool, so they don't stay allocated.
+const maxPooledBuffer = 256 << 10
+
+// buffers hold the request bodies while they are sent and the responses
+// while they are read. io.ReadAll grows its slice over and over for every
+// response, a pooled buffer has usually room for the whole body and the
+// response gets a single copy of the exact size.
+var buffers = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// requestBody is the JSON body of a request, encoded once into a pooled
+// buffer and read by every attempt. The buffer goes back to the pool when
+// the attempts end, unless a transport didn't close one of its readers and
+// could still be reading it.
+type requestBody struct {
+	buf  *bytes.Buffer
+	open atomic.Int32
+}
+
+// encodeBody encodes data, which is sent as is when it is a []byte. A nil
+// data has no body.
+func encodeBody(data any) (*requestBody, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	body := &requestBody{buf: buffers.Get().(*bytes.Buffer)}
+	if b, ok := data.([]byte); ok {
+		body.buf.Write(b)
+		return body, nil
+	}
+	if err := json.NewEncoder(body.buf).Encode(data); err != nil {
+		body.release()
+		return nil, fmt.Errorf("failed to marshal data: %w", err)
+	}
+	// Encode ends with a newline, json.Marshal doesn't.
+	body.buf.Truncate(body.buf.Len() - 1)
+
+	return body, nil
+}
+
+func (b *requestBody) reader() io.ReadCloser {
+	b.open.Add(1)
+	return &bodyReader{Reader: bytes.NewReader(b.buf.Bytes()), body: b}
+}
+
+func (b *requestBody) release() {
+	if b == nil || b.open.Load() != 0 || b.buf.Cap() > maxPooledBuffer {
+		return
+	}
+	b.buf.Reset()
+	buffers.Put(b.buf)
+}
+
+// bodyReader is a reader of a requestBody, the transport closes it once
+// the body was sent.
+type bodyReader struct {
+	*bytes.Reader
+	body   *requestBody
+	closed atomic.Bool
+}
+
+func (r *bodyReader) Close() error {
+	if r.closed.CompareAndSwap(false, true) {
+		r.body.open.Add(-1)
+	}
+
+	return nil
+}
+
+// read reads the whole body of resp, which must be JSON when it isn't empty.
+func (c *Client) read(resp *http.Response) (int, []byte, error) {
+	buf := buffers.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			buf.Reset()
+			buffers.Put(buf)
+		}
+	}()
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	respBody := bytes.Clone(buf.Bytes())
+
+	contentType := resp.Header.Get("Content-Type")
+	if len(bytes.TrimSpace(respBody)) > 0 && !isJSON(contentType, respBody) {
+		return resp.StatusCode, respBody, newNonJSONError(resp.StatusCode, contentType, respBody)
+	}
+
+	return resp.StatusCode, respBody, nil
+}
+
+// Decode unmarshals the body of a successful response into v. Unknown
+// fields are ignored, OnDecode can report them.
+func (c *Client) Decode(ctx context.Context, statusCode int, body []byte, v any) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return newNonJSONError(statusCode, "", nil)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if c.OnDecode != nil {
+		c.OnDecode(ctx, body, v)
+	}
+
+	return nil
+}
+
+// StreamCourses sends every course of the catalog to the first channel,
+// fetching DefaultPageSize courses per request with one page of prefetch.
+// Read the error channel once the first one is closed, like the example
+// does.
+func (c *Client) StreamCourses(ctx context.Context, opts ...CallOption) (<-chan Course, <-chan error) {
+	return NewCourseIterator(c.Courses, 1, DefaultPageSize, opts...).Stream(ctx)
+}
+
+// isJSON reports whether the response is JSON. Some endpoints don't send the
+// content type, so the body is checked when it is missing or generic.
+func isJSON(contentType string, body []byte) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")) {
+		return true
+	}
+	if err == nil && mediaType != "text/plain" && mediaType != "application/octet-stream" {
+		return false
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed)
+}
@@ -0,0 +1,152 @@
+package edteam_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+	"edteam-mcp/testsupport"
+)
+
+// fastRetries retries like DefaultRetryPolicy without the waits.
+var fastRetries = edteam.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+func TestResponseErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		status      int
+		body        string
+		check       func(t *testing.T, err error)
+	}{
+		{
+			name:        "bad status",
+			contentType: "application/json",
+			status:      http.StatusInternalServerError,
+			body:        `{"messages":[{"title":"Error","message":"something failed","code":"E500"}]}`,
+			check: func(t *testing.T, err error) {
+				var statusErr *edteam.StatusError
+				if !errors.As(err, &statusErr) || statusErr.Code != http.StatusInternalServerError || statusErr.Message() != "Error: something failed" {
+					t.Errorf("error = %v, want a *StatusError with the message of EDteam", err)
+				}
+			},
+		},
+		{
+			name:        "malformed JSON",
+			contentType: "application/json",
+			status:      http.StatusOK,
+			body:        `{"data":[{"id":`,
+			check: func(t *testing.T, err error) {
+				if err == nil || !strings.Contains(err.Error(), "failed to") {
+					t.Errorf("error = %v, want a decoding error", err)
+				}
+			},
+		},
+		{
+			name:        "HTML page",
+			contentType: "text/html",
+			status:      http.StatusOK,
+			body:        "<html><body>Under maintenance</body></html>",
+			check: func(t *testing.T, err error) {
+				var nonJSONErr *edteam.NonJSONError
+				if !errors.As(err, &nonJSONErr) || !strings.Contains(nonJSONErr.Snippet, "Under maintenance") {
+					t.Errorf("error = %v, want a *NonJSONError with the page", err)
+				}
+			},
+		},
+		{
+			name:        "empty body",
+			contentType: "application/json",
+			status:      http.StatusOK,
+			check: func(t *testing.T, err error) {
+				var nonJSONErr *edteam.NonJSONError
+				if !errors.As(err, &nonJSONErr) {
+					t.Errorf("error = %v, want a *NonJSONError", err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newFakeClient(t)
+			respond := func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}
+			fake.Handle(http.MethodPost, "/v2/public/cache-edql", respond)
+			fake.Handle(http.MethodGet, "/api/v1/subscriptions/historical", respond)
+
+			// The catalog is decoded from the connection, the subscriptions
+			// once read.
+			_, err := client.Courses.List(context.Background(), 1, 10)
+			tt.check(t, err)
+			_, err = client.Subscriptions.List(context.Background(), testsupport.Token)
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestResponseTooLarge(t *testing.T) {
+	client, _ := newFakeClient(t, edteam.WithMaxResponseSize(64))
+
+	_, err := client.Courses.List(context.Background(), 1, 10)
+	var tooLargeErr *edteam.ResponseTooLargeError
+	if !errors.As(err, &tooLargeErr) || tooLargeErr.Limit != 64 {
+		t.Errorf("Courses.List() error = %v, want a *ResponseTooLargeError", err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	client, fake := newFakeClient(t, edteam.WithRetryPolicy(fastRetries))
+	fake.Handle(http.MethodGet, "/api/v1/subscriptions/historical", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.Subscriptions.List(ctx, testsupport.Token)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Subscriptions.List() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Subscriptions.List() returned after %v, want it to stop at the deadline", elapsed)
+	}
+	// A request past its deadline isn't retried.
+	if got := len(fake.Requests()); got != 1 {
+		t.Errorf("Subscriptions.List() sent %d requests, want 1", got)
+	}
+}
+
+func TestRetryTransientStatus(t *testing.T) {
+	client, fake := newFakeClient(t, edteam.WithRetryPolicy(fastRetries))
+	var calls int
+	fake.Handle(http.MethodPost, "/v2/public/cache-edql", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"messages":[]}`))
+			return
+		}
+		w.Write(testsupport.Fixture(t, "courses"))
+	})
+
+	courses, err := client.Courses.List(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("Courses.List() error = %v", err)
+	}
+	if len(courses.Data) == 0 {
+		t.Error("Courses.List() returned no courses")
+	}
+	// The body is sent again by every attempt.
+	requests := fake.Requests()
+	if len(requests) != 3 || string(requests[2].Body) != string(requests[0].Body) {
+		t.Errorf("Courses.List() sent %d requests, want 3 with the same body", len(requests))
+	}
+}
@@ -0,0 +1,114 @@
+package edteam
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	urlAPICourses = "https://api.ed.team/api/v1/courses"
+	urlThreads    = "https://api.ed.team/api/v1/threads"
+)
+
+// CommunityService reads and publishes what the students share about the
+// courses: the community threads and the reviews.
+type CommunityService struct {
+	client *Client
+}
+
+// Threads returns a page of the threads of the community of a course, the
+// pages start at 1.
+func (s *CommunityService) Threads(ctx context.Context, token string, courseID, page, limit int, opts ...CallOption) (ThreadsResponse, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+	urlThreads := fmt.Sprintf("%s/%d/threads?%s", urlAPICourses, courseID, query.Encode())
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodGet, urlThreads, token, nil, opts...)
+	if err != nil {
+		return ThreadsResponse{}, err
+	}
+	if statusCode != http.StatusOK {
+		return ThreadsResponse{}, unconfirmedStatusError(http.MethodGet, urlThreads, statusCode, responseBody)
+	}
+	// Parse the response
+	var threads ThreadsResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &threads)
+	if err != nil {
+		return ThreadsResponse{}, err
+	}
+
+	return threads, nil
+}
+
+// Thread returns a thread with its question and all the answers.
+func (s *CommunityService) Thread(ctx context.Context, token string, threadID int, opts ...CallOption) (ThreadResponse, error) {
+	urlThread := fmt.Sprintf("%s/%d", urlThreads, threadID)
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodGet, urlThread, token, nil, opts...)
+	if err != nil {
+		return ThreadResponse{}, err
+	}
+	if statusCode != http.StatusOK {
+		return ThreadResponse{}, NewStatusError(statusCode, responseBody)
+	}
+	// Parse the response
+	var thread ThreadResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &thread)
+	if err != nil {
+		return ThreadResponse{}, err
+	}
+
+	return thread, nil
+}
+
+// PostQuestion opens a new thread in the community of the course. It is not
+// retried, a retry could publish the question twice.
+func (s *CommunityService) PostQuestion(ctx context.Context, token string, courseID int, title, question string, opts ...CallOption) (ThreadResponse, error) {
+	urlThreads := fmt.Sprintf("%s/%d/threads", urlAPICourses, courseID)
+	thread := struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	}{title, question}
+	statusCode, responseBody, err := s.client.Send(ctx, false, http.MethodPost, urlThreads, token, thread, opts...)
+	if err != nil {
+		return ThreadResponse{}, err
+	}
+	if statusCode != http.StatusCreated {
+		return ThreadResponse{}, unconfirmedStatusError(http.MethodPost, urlThreads, statusCode, responseBody)
+	}
+	// Parse the response
+	var created ThreadResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &created)
+	if err != nil {
+		return ThreadResponse{}, err
+	}
+
+	return created, nil
+}
+
+// SubmitReview rates a course. It is not retried, a retry could publish the
+// review twice.
+func (s *CommunityService) SubmitReview(ctx context.Context, token string, courseID, rating int, text string, opts ...CallOption) (ReviewResponse, error) {
+	urlReviews := fmt.Sprintf("%s/%d/reviews", urlAPICourses, courseID)
+	review := struct {
+		Rating int    `json:"rating"`
+		Text   string `json:"text"`
+	}{rating, text}
+	statusCode, responseBody, err := s.client.Send(ctx, false, http.MethodPost, urlReviews, token, review, opts...)
+	if err != nil {
+		return ReviewResponse{}, err
+	}
+	if statusCode != http.StatusCreated {
+		return ReviewResponse{}, unconfirmedStatusError(http.MethodPost, urlReviews, statusCode, responseBody)
+	}
+	// Parse the response
+	var response ReviewResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &response)
+	if err != nil {
+		return ReviewResponse{}, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,55 @@
+package edteam
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	urlLiveEvents = "https://api.ed.team/api/v1/public/live-events/upcoming"
+	urlBlogPosts  = "https://api.ed.team/api/v1/public/blog/posts?limit=50"
+)
+
+// ContentService reads what EDteam publishes besides the catalog, it needs
+// no token.
+type ContentService struct {
+	client *Client
+}
+
+// LiveEvents returns the upcoming live classes.
+func (s *ContentService) LiveEvents(ctx context.Context, opts ...CallOption) (LiveEventsResponse, error) {
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodGet, urlLiveEvents, "", nil, opts...)
+	if err != nil {
+		return LiveEventsResponse{}, err
+	}
+	if statusCode != http.StatusOK {
+		return LiveEventsResponse{}, unconfirmedStatusError(http.MethodGet, urlLiveEvents, statusCode, responseBody)
+	}
+	// Parse the response
+	var events LiveEventsResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &events)
+	if err != nil {
+		return LiveEventsResponse{}, err
+	}
+
+	return events, nil
+}
+
+// BlogPosts returns the latest 50 articles of the blog.
+func (s *ContentService) BlogPosts(ctx context.Context, opts ...CallOption) (BlogPostsResponse, error) {
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodGet, urlBlogPosts, "", nil, opts...)
+	if err != nil {
+		return BlogPostsResponse{}, err
+	}
+	if statusCode != http.StatusOK {
+		return BlogPostsResponse{}, unconfirmedStatusError(http.MethodGet, urlBlogPosts, statusCode, responseBody)
+	}
+	// Parse the response
+	var posts BlogPostsResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &posts)
+	if err != nil {
+		return BlogPostsResponse{}, err
+	}
+
+	return posts, nil
+}
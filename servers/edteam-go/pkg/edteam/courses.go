@@ -0,0 +1,255 @@
+package edteam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+const urlCacheEDQL = "https://jarvis-v2.ed.team/v2/public/cache-edql"
+
+// CoursesService reads the public catalog, it needs no token.
+type CoursesService struct {
+	client *Client
+}
+
+// List returns a page of the catalog, the pages start at 1.
+func (s *CoursesService) List(ctx context.Context, page, limit uint, opts ...CallOption) (CourseResponse, error) {
+	var courses CourseResponse
+	if err := s.listInto(ctx, page, limit, &courses, opts...); err != nil {
+		return CourseResponse{}, err
+	}
+
+	return courses, nil
+}
+
+// listInto decodes a page of the catalog into courses, reusing the array of
+// courses.Data when it has room for the page.
+func (s *CoursesService) listInto(ctx context.Context, page, limit uint, courses *CourseResponse, opts ...CallOption) error {
+	body, err := coursesQuery(page, limit)
+	if err != nil {
+		return err
+	}
+	statusCode, responseBody, err := s.client.SendDecode(ctx, true, http.MethodPost, urlCacheEDQL, "", body, courses, opts...)
+	if err != nil {
+		return err
+	}
+	if statusCode != http.StatusOK {
+		return NewStatusError(statusCode, responseBody)
+	}
+
+	return nil
+}
+
+// pageLister is implemented by the CoursesAPI that decode a page into the
+// slice of the previous one, the others are called with List.
+type pageLister interface {
+	listInto(ctx context.Context, page, limit uint, courses *CourseResponse, opts ...CallOption) error
+}
+
+// coursesQuery builds the cache-edql body that requests a page of courses.
+func coursesQuery(page, limit uint) ([]byte, error) {
+	if page == 0 || limit == 0 {
+		return nil, fmt.Errorf("page and limit must be greater than 0, got page %d and limit %d", page, limit)
+	}
+
+	query := struct {
+		Name string `json:"name"`
+	}{
+		Name: fmt.Sprintf("cache:GENERAL:page(%d):limit(%d):key(COURSES_GRID_PAGINATION)", page, limit),
+	}
+
+	return json.Marshal(query)
+}
+
+// slugPattern matches the slugs of the courses, like go-desde-cero.
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Curriculum returns the modules of the course with their classes.
+func (s *CoursesService) Curriculum(ctx context.Context, slug string, opts ...CallOption) (CurriculumResponse, error) {
+	var curriculum CurriculumResponse
+	if err := s.page(ctx, slug, "COURSE_CURRICULUM", &curriculum, opts); err != nil {
+		return CurriculumResponse{}, err
+	}
+
+	return curriculum, nil
+}
+
+// FAQ returns the frequently asked questions of the course page.
+func (s *CoursesService) FAQ(ctx context.Context, slug string, opts ...CallOption) (FAQResponse, error) {
+	var faq FAQResponse
+	if err := s.page(ctx, slug, "COURSE_FAQ", &faq, opts); err != nil {
+		return FAQResponse{}, err
+	}
+
+	return faq, nil
+}
+
+// Trailer returns the trailer of the course.
+func (s *CoursesService) Trailer(ctx context.Context, slug string, opts ...CallOption) (TrailerResponse, error) {
+	var trailer TrailerResponse
+	if err := s.page(ctx, slug, "COURSE_TRAILER", &trailer, opts); err != nil {
+		return TrailerResponse{}, err
+	}
+
+	return trailer, nil
+}
+
+// page decodes the section key of the page of the course into v.
+func (s *CoursesService) page(ctx context.Context, slug, key string, v any, opts []CallOption) error {
+	body, err := courseQuery(slug, key)
+	if err != nil {
+		return err
+	}
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodPost, urlCacheEDQL, "", body, opts...)
+	if err != nil {
+		return err
+	}
+	if statusCode != http.StatusOK {
+		return NewStatusError(statusCode, responseBody)
+	}
+
+	return s.client.Decode(ctx, statusCode, responseBody, v)
+}
+
+// courseQuery builds the cache-edql body that requests one of the sections of
+// a course page, like COURSE_CURRICULUM or COURSE_FAQ.
+func courseQuery(slug, key string) ([]byte, error) {
+	if !slugPattern.MatchString(slug) {
+		return nil, fmt.Errorf("invalid course slug %q", slug)
+	}
+
+	query := struct {
+		Name string `json:"name"`
+	}{
+		Name: fmt.Sprintf("cache:GENERAL:slug(%s):key(%s)", slug, key),
+	}
+
+	return json.Marshal(query)
+}
+
+// Duration returns the number of classes and the total duration in seconds.
+func (c CurriculumResponse) Duration() (int, int) {
+	var classes, seconds int
+	for _, section := range c.Data {
+		for _, class := range section.Classes {
+			classes++
+			seconds += class.Duration
+		}
+	}
+
+	return classes, seconds
+}
+
+// CourseIterator walks the catalog one course at a time, fetching the pages
+// as it goes. The walk ends with the first page shorter than the limit.
+type CourseIterator struct {
+	api         CoursesAPI
+	page, limit uint
+	opts        []CallOption
+
+	// MaxPages stops the walk after that many pages, so an upstream that
+	// never returns a short page can't make it endless. Zero has no limit.
+	MaxPages int
+
+	fetched int
+	// buf holds the page being walked, its array is reused by the next one.
+	buf     CourseResponse
+	courses []Course
+	current Course
+	last    bool
+	err     error
+}
+
+// NewCourseIterator returns an iterator over the catalog of courses from
+// page on, fetching limit courses per request with opts.
+func NewCourseIterator(courses CoursesAPI, page, limit uint, opts ...CallOption) *CourseIterator {
+	return &CourseIterator{api: courses, page: page, limit: limit, opts: opts}
+}
+
+// Next moves to the next course, fetching the next page when the current
+// one is done. It returns false at the end of the catalog or when a page
+// fails, Err tells them apart.
+func (it *CourseIterator) Next(ctx context.Context) bool {
+	for len(it.courses) == 0 {
+		if it.err != nil || it.last || it.MaxPages > 0 && it.fetched >= it.MaxPages {
+			return false
+		}
+
+		courses, err := it.list(ctx)
+		if err != nil {
+			it.err = fmt.Errorf("failed to fetch page %d of the catalog: %w", it.page, err)
+			return false
+		}
+		it.fetched++
+		it.page++
+		it.courses = courses
+		it.last = uint(len(courses)) < it.limit
+	}
+
+	it.current, it.courses = it.courses[0], it.courses[1:]
+	return true
+}
+
+// list fetches the current page. The pages after the first one have the
+// same shape, so only the first one is passed to OnDecode.
+func (it *CourseIterator) list(ctx context.Context) ([]Course, error) {
+	if it.fetched > 0 {
+		ctx = WithoutInspection(ctx)
+	}
+	lister, ok := it.api.(pageLister)
+	if !ok {
+		courses, err := it.api.List(ctx, it.page, it.limit, it.opts...)
+		return courses.Data, err
+	}
+
+	// Every course of the previous page was handed out by value. The decoder
+	// keeps the fields missing from the JSON, so the array is cleared first.
+	clear(it.buf.Data[:cap(it.buf.Data)])
+	it.buf.Data = it.buf.Data[:0]
+	err := lister.listInto(ctx, it.page, it.limit, &it.buf, it.opts...)
+
+	return it.buf.Data, err
+}
+
+// Course returns the course Next moved to.
+func (it *CourseIterator) Course() Course {
+	return it.current
+}
+
+// Err returns the error that stopped the walk, nil when it reached the end of
+// the catalog.
+func (it *CourseIterator) Err() error {
+	return it.err
+}
+
+// Stream sends the rest of the courses of it to the first channel from a
+// goroutine, which fetches the next page while the current one is consumed.
+// The channel holds one page, so the goroutine waits for the consumer instead
+// of reading the catalog ahead. The second channel receives the error that
+// stopped the walk, if any, after the first one is closed. Cancel ctx to
+// stop early.
+func (it *CourseIterator) Stream(ctx context.Context) (<-chan Course, <-chan error) {
+	courses := make(chan Course, it.limit)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(courses)
+
+		for it.Next(ctx) {
+			select {
+			case courses <- it.Course():
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			errs <- err
+		}
+	}()
+
+	return courses, errs
+}
@@ -0,0 +1,121 @@
+package edteam_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+	"edteam-mcp/pkg/edteam/edteammock"
+	"edteam-mcp/testsupport"
+)
+
+func TestCoursesList(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	courses, err := client.Courses.List(context.Background(), 2, 10)
+	if err != nil {
+		t.Fatalf("Courses.List() error = %v", err)
+	}
+	if len(courses.Data) == 0 || courses.Data[0].Course.Slug != "go-avanzado" {
+		t.Errorf("Courses.List() = %+v, want the courses of the fixture", courses.Data)
+	}
+
+	var query struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(fake.Requests()[0].Body, &query); err != nil {
+		t.Fatalf("the cache-edql body isn't JSON: %v", err)
+	}
+	if want := "cache:GENERAL:page(2):limit(10):key(COURSES_GRID_PAGINATION)"; query.Name != want {
+		t.Errorf("the cache-edql query = %q, want %q", query.Name, want)
+	}
+}
+
+func TestCoursesListInvalidPage(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	for _, tt := range []struct{ page, limit uint }{{0, 10}, {1, 0}} {
+		if _, err := client.Courses.List(context.Background(), tt.page, tt.limit); err == nil {
+			t.Errorf("Courses.List(%d, %d) error = nil, want an error", tt.page, tt.limit)
+		}
+	}
+	if got := len(fake.Requests()); got != 0 {
+		t.Errorf("Courses.List() sent %d requests, want none", got)
+	}
+}
+
+// endlessCatalog answers every page of the catalog in full, the walk never
+// ends on its own.
+func endlessCatalog(t *testing.T, fake *testsupport.FakeEDteam) {
+	t.Helper()
+
+	var fixture edteam.CourseResponse
+	if err := json.Unmarshal(testsupport.Fixture(t, "courses"), &fixture); err != nil {
+		t.Fatal(err)
+	}
+	page := edteam.CourseResponse{Data: make([]edteam.Course, edteam.DefaultPageSize)}
+	for i := range page.Data {
+		page.Data[i] = fixture.Data[i%len(fixture.Data)]
+	}
+	body, err := json.Marshal(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.RespondWith(http.MethodPost, "/v2/public/cache-edql", http.StatusOK, body)
+}
+
+func TestStreamCoursesCancelled(t *testing.T) {
+	client, fake := newFakeClient(t)
+	endlessCatalog(t, fake)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	courses, errs := client.StreamCourses(ctx)
+	if _, ok := <-courses; !ok {
+		t.Fatal("StreamCourses() sent no course")
+	}
+	cancel()
+
+	done := make(chan error)
+	go func() {
+		for range courses {
+		}
+		done <- <-errs
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("StreamCourses() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("StreamCourses() kept sending courses after the context was cancelled")
+	}
+}
+
+func TestCourseIteratorStreamCancelled(t *testing.T) {
+	api := &edteammock.CoursesAPIMock{
+		ListFunc: func(ctx context.Context, page, limit uint, opts ...edteam.CallOption) (edteam.CourseResponse, error) {
+			if err := ctx.Err(); err != nil {
+				return edteam.CourseResponse{}, err
+			}
+			return edteam.CourseResponse{Data: make([]edteam.Course, limit)}, nil
+		},
+	}
+
+	// Nobody reads the courses, the goroutine waits on the full channel
+	// until the context is cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	courses, errs := edteam.NewCourseIterator(api, 1, 5).Stream(ctx)
+	cancel()
+	for range courses {
+	}
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("Stream() error = %v, want context.Canceled", err)
+	}
+	if calls := len(api.ListCalls()); calls > 3 {
+		t.Errorf("Stream() fetched %d pages after the context was cancelled, want it to stop", calls)
+	}
+}
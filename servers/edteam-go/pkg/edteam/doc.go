@@ -0,0 +1,61 @@
+// Package edteam is a client for the EDteam API, the one behind the EDteam
+// MCP server of the course. It can be imported into other MCP servers or
+// apps:
+//
+//	client, err := edteam.New(edteam.WithUserAgent("my-app/1.0"))
+//	if err != nil {
+//		return err
+//	}
+//
+//	courses, err := client.Courses.List(ctx, 1, 10)
+//	if err != nil {
+//		return err
+//	}
+//	for _, item := range courses.Data {
+//		fmt.Println(item.Course.ID, item.Course.Name)
+//	}
+//
+// A CourseIterator walks the whole catalog without a loop over the pages:
+//
+//	it := edteam.NewCourseIterator(client.Courses, 1, 50)
+//	for it.Next(ctx) {
+//		course := it.Course()
+//		fmt.Println(course.Course.Name)
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+//
+// StreamCourses does the same from a goroutine that fetches the next page
+// while the current one is processed.
+//
+// The endpoints of the account take the token returned by Login:
+//
+//	token, err := client.Login(ctx, email, password)
+//	if err != nil {
+//		return err
+//	}
+//	subscriptions, err := client.Subscriptions.List(ctx, token)
+//
+// Failed calls return a *StatusError with the status code and the messages
+// sent by EDteam, or a *NonJSONError when EDteam answers with something else
+// than JSON, like a maintenance page. Both match ErrUnauthorized,
+// ErrNotFound and ErrRateLimited for errors.Is:
+//
+//	_, err = client.Cart.Add(ctx, token, courseID)
+//	if errors.Is(err, edteam.ErrUnauthorized) {
+//		// Log in again.
+//	}
+//	var statusErr *edteam.StatusError
+//	if errors.As(err, &statusErr) {
+//		fmt.Println(statusErr.Code, statusErr.Message())
+//	}
+//
+// Some endpoints aren't in the public docs of EDteam, their paths come from
+// the web app. Their 404 is an *UnavailableError, which matches
+// ErrEndpointUnavailable.
+//
+// The transient failures are retried following Client.Retry. The requests
+// that aren't idempotent, like adding a course to the cart, are only retried
+// when EDteam surely didn't process them.
+package edteam
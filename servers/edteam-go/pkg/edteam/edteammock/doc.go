@@ -0,0 +1,16 @@
+// Package edteammock has the mocks of the service interfaces of the edteam
+// package, to stub EDteam in tests without a fake server:
+//
+//	client, err := edteam.New()
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	client.Courses = &edteammock.CoursesAPIMock{
+//		ListFunc: func(ctx context.Context, page, limit uint, opts ...edteam.CallOption) (edteam.CourseResponse, error) {
+//			return edteam.CourseResponse{}, edteam.ErrNotFound
+//		},
+//	}
+//
+// Regenerate them with go generate in the edteam package after changing an
+// interface.
+package edteammock
@@ -0,0 +1,196 @@
+package edteam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Message is the explanation EDteam sends with the errors and with some
+// successful responses.
+type Message struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+// The kinds of failure callers usually branch on. A *StatusError or a
+// *NonJSONError with the matching status is one of them for errors.Is:
+//
+//	if errors.Is(err, edteam.ErrUnauthorized) {
+//		// Log in again.
+//	}
+var (
+	ErrUnauthorized = errors.New("edteam: unauthorized")
+	ErrNotFound     = errors.New("edteam: not found")
+	ErrRateLimited  = errors.New("edteam: rate limited")
+
+	// ErrEndpointUnavailable is matched by an *UnavailableError.
+	ErrEndpointUnavailable = errors.New("edteam: endpoint unavailable")
+)
+
+// statusErrors are the sentinel errors of the status codes.
+var statusErrors = map[int]error{
+	http.StatusUnauthorized:    ErrUnauthorized,
+	http.StatusNotFound:        ErrNotFound,
+	http.StatusTooManyRequests: ErrRateLimited,
+}
+
+// StatusError is returned when EDteam answers with an unexpected status code.
+type StatusError struct {
+	Code     int
+	Body     []byte
+	Messages []Message
+}
+
+// NewStatusError returns the error of a response with an unexpected status,
+// with the messages of body when it has any.
+func NewStatusError(statusCode int, body []byte) *StatusError {
+	var response struct {
+		Messages []Message `json:"messages"`
+	}
+	// The body is not always JSON, keep the raw body in that case.
+	_ = json.Unmarshal(body, &response)
+
+	return &StatusError{
+		Code:     statusCode,
+		Body:     body,
+		Messages: response.Messages,
+	}
+}
+
+func (e *StatusError) Error() string {
+	if message := e.Message(); message != "" {
+		return fmt.Sprintf("unexpected status code: %d: %s", e.Code, message)
+	}
+
+	return fmt.Sprintf("unexpected status code: %d", e.Code)
+}
+
+// Is matches the sentinel error of the status code, like ErrNotFound for a
+// 404.
+func (e *StatusError) Is(target error) bool {
+	return target != nil && statusErrors[e.Code] == target
+}
+
+// Message returns the messages sent by EDteam joined in a single string.
+func (e *StatusError) Message() string {
+	messages := make([]string, 0, len(e.Messages))
+	for _, m := range e.Messages {
+		switch {
+		case m.Title != "" && m.Message != "":
+			messages = append(messages, m.Title+": "+m.Message)
+		case m.Message != "":
+			messages = append(messages, m.Message)
+		case m.Title != "":
+			messages = append(messages, m.Title)
+		}
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// NonJSONError is returned when EDteam answers with something that is not
+// JSON, like the HTML pages served during maintenance or by Cloudflare.
+type NonJSONError struct {
+	StatusCode  int
+	ContentType string
+	Snippet     string
+}
+
+const snippetSize = 200
+
+func newNonJSONError(statusCode int, contentType string, body []byte) *NonJSONError {
+	snippet := strings.Join(strings.Fields(string(body)), " ")
+	if len(snippet) > snippetSize {
+		snippet = strings.ToValidUTF8(snippet[:snippetSize], "") + "..."
+	}
+
+	return &NonJSONError{
+		StatusCode:  statusCode,
+		ContentType: contentType,
+		Snippet:     snippet,
+	}
+}
+
+func (e *NonJSONError) Error() string {
+	if e.Snippet == "" {
+		return fmt.Sprintf("upstream returned an empty body (status %d)", e.StatusCode)
+	}
+	if e.ContentType != "" {
+		return fmt.Sprintf("upstream returned non-JSON (status %d, %s): %s", e.StatusCode, e.ContentType, e.Snippet)
+	}
+
+	return fmt.Sprintf("upstream returned non-JSON (status %d): %s", e.StatusCode, e.Snippet)
+}
+
+// Is matches the sentinel error of the status code, a proxy in front of
+// EDteam may answer a 429 with an HTML page.
+func (e *NonJSONError) Is(target error) bool {
+	return target != nil && statusErrors[e.StatusCode] == target
+}
+
+// ResponseTooLargeError is returned when a response is longer than the
+// limit of WithMaxResponseSize, its reading is abandoned there.
+type ResponseTooLargeError struct {
+	StatusCode int
+	Limit      int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response too large: EDteam sent more than %d bytes (status %d)", e.Limit, e.StatusCode)
+}
+
+// AmbiguousError is returned when a non idempotent request failed after it
+// could have reached EDteam, so it is unknown whether it was processed.
+type AmbiguousError struct {
+	Err error
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("the request may have been processed by EDteam: %v", e.Err)
+}
+
+func (e *AmbiguousError) Unwrap() error {
+	return e.Err
+}
+
+// UnavailableError is returned when EDteam answers 404 to an endpoint that
+// isn't in its public docs. Their paths were taken from the web app, EDteam
+// may have moved them or never opened them to the API. The 404 still
+// matches ErrNotFound through Err.
+//
+// The endpoints of a record, like a thread or the seats of a member, keep
+// the *StatusError: their 404 usually means the record doesn't exist.
+type UnavailableError struct {
+	Method string
+	URL    string
+	Err    *StatusError
+}
+
+// unconfirmedStatusError returns the error of an unexpected status from one
+// of those endpoints: an *UnavailableError for a 404, a *StatusError
+// otherwise.
+func unconfirmedStatusError(method, url string, statusCode int, body []byte) error {
+	err := NewStatusError(statusCode, body)
+	if statusCode != http.StatusNotFound {
+		return err
+	}
+
+	return &UnavailableError{Method: method, URL: url, Err: err}
+}
+
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("endpoint unavailable: EDteam answered 404 to %s %s, it isn't part of its public API", e.Method, e.URL)
+}
+
+// Is matches ErrEndpointUnavailable.
+func (e *UnavailableError) Is(target error) bool {
+	return target == ErrEndpointUnavailable
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,47 @@
+package edteam_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"edteam-mcp/pkg/edteam"
+	"edteam-mcp/testsupport"
+)
+
+func TestUnconfirmedEndpointNotFound(t *testing.T) {
+	client, fake := newFakeClient(t)
+	ctx := context.Background()
+
+	// The fake EDteam answers 404 to the routes it doesn't know.
+	_, err := client.Billing.Gift(ctx, testsupport.Token, 101, "friend@example.com")
+	var unavailableErr *edteam.UnavailableError
+	if !errors.As(err, &unavailableErr) {
+		t.Fatalf("Billing.Gift() error = %v, want an *UnavailableError", err)
+	}
+	if unavailableErr.Method != http.MethodPost || unavailableErr.Err.Code != http.StatusNotFound {
+		t.Errorf("Billing.Gift() error = %s with status %d, want POST with status 404", unavailableErr.Method, unavailableErr.Err.Code)
+	}
+	if !errors.Is(err, edteam.ErrEndpointUnavailable) || !errors.Is(err, edteam.ErrNotFound) {
+		t.Errorf("Billing.Gift() error = %v, want it to match ErrEndpointUnavailable and ErrNotFound", err)
+	}
+
+	_, found, err := client.Account.LastWatched(ctx, testsupport.Token)
+	if !errors.Is(err, edteam.ErrEndpointUnavailable) || found {
+		t.Errorf("Account.LastWatched() = %v, %v, want ErrEndpointUnavailable", found, err)
+	}
+
+	// A 404 of a record means the record doesn't exist.
+	_, err = client.Community.Thread(ctx, testsupport.Token, 7)
+	if !errors.Is(err, edteam.ErrNotFound) || errors.Is(err, edteam.ErrEndpointUnavailable) {
+		t.Errorf("Community.Thread() error = %v, want ErrNotFound only", err)
+	}
+
+	fake.RespondWith(http.MethodGet, "/v2/private/billing-address", http.StatusInternalServerError, []byte(`{"messages":[]}`))
+	_, err = client.Billing.Address(ctx, testsupport.Token)
+	var statusErr *edteam.StatusError
+	if !errors.As(err, &statusErr) || errors.Is(err, edteam.ErrEndpointUnavailable) {
+		t.Errorf("Billing.Address() error = %v, want a *StatusError", err)
+	}
+}
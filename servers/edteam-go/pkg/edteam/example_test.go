@@ -0,0 +1,187 @@
+package edteam_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"edteam-mcp/pkg/edteam"
+)
+
+// exampleCatalog is the catalog of the stand-in for EDteam.
+var exampleCatalog = []string{"Go desde cero", "Go Avanzado", "Docker"}
+
+var examplePage = regexp.MustCompile(`page\((\d+)\):limit\((\d+)\)`)
+
+// exampleEDteam stands in for EDteam in the examples, it knows the account
+// ana@example.com with the password secret and the catalog above.
+func exampleEDteam() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/login", func(w http.ResponseWriter, r *http.Request) {
+		var login edteam.Login
+		json.NewDecoder(r.Body).Decode(&login)
+		if login.Email != "ana@example.com" || login.Password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"messages":[{"title":"Error","message":"invalid email or password"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"token":"token-of-ana"}}`)
+	})
+	mux.HandleFunc("POST /v2/public/cache-edql", func(w http.ResponseWriter, r *http.Request) {
+		var query struct {
+			Name string `json:"name"`
+		}
+		json.NewDecoder(r.Body).Decode(&query)
+		match := examplePage.FindStringSubmatch(query.Name)
+		page, _ := strconv.Atoi(match[1])
+		limit, _ := strconv.Atoi(match[2])
+
+		courses := edteam.CourseResponse{Data: []edteam.Course{}}
+		for i := (page - 1) * limit; i < min(page*limit, len(exampleCatalog)); i++ {
+			courses.Data = append(courses.Data, edteam.Course{Course: edteam.CourseDetails{ID: i + 1, Name: exampleCatalog[i]}})
+		}
+		json.NewEncoder(w).Encode(courses)
+	})
+	mux.HandleFunc("GET /api/v1/subscriptions/historical", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-of-ana" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"messages":[{"title":"Unauthorized","message":"the token expired"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"id":1,"months":12,"state":"active"}]}`)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"messages":[{"title":"Not found","message":"no such endpoint"}]}`)
+	})
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mux.ServeHTTP(w, r)
+	}))
+}
+
+// exampleClient returns a client of exampleEDteam. A real one talks to
+// EDteam with edteam.New() and no options.
+func exampleClient() (*edteam.Client, func()) {
+	fake := exampleEDteam()
+	client, err := edteam.New(edteam.WithBaseURL(fake.URL), edteam.WithUserAgent("my-app/1.0"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return client, fake.Close
+}
+
+func ExampleClient_Login() {
+	client, done := exampleClient()
+	defer done()
+	ctx := context.Background()
+
+	token, err := client.Login(ctx, "ana@example.com", "secret")
+	if err != nil {
+		log.Fatal(err)
+	}
+	subscriptions, err := client.Subscriptions.List(ctx, token)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, subscription := range subscriptions.Data {
+		fmt.Println(subscription.ID, subscription.State, subscription.Months, "months")
+	}
+	// Output: 1 active 12 months
+}
+
+func ExampleCoursesService_List() {
+	client, done := exampleClient()
+	defer done()
+
+	// The pages start at 1.
+	courses, err := client.Courses.List(context.Background(), 1, 2)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, item := range courses.Data {
+		fmt.Println(item.Course.ID, item.Course.Name)
+	}
+	// Output:
+	// 1 Go desde cero
+	// 2 Go Avanzado
+}
+
+func ExampleCourseIterator() {
+	client, done := exampleClient()
+	defer done()
+	ctx := context.Background()
+
+	// Two courses per request, the iterator asks for the pages as it goes.
+	it := edteam.NewCourseIterator(client.Courses, 1, 2)
+	for it.Next(ctx) {
+		fmt.Println(it.Course().Course.Name)
+	}
+	if err := it.Err(); err != nil {
+		log.Fatal(err)
+	}
+	// Output:
+	// Go desde cero
+	// Go Avanzado
+	// Docker
+}
+
+func ExampleClient_StreamCourses() {
+	client, done := exampleClient()
+	defer done()
+
+	courses, errs := client.StreamCourses(context.Background())
+	var names []string
+	for course := range courses {
+		names = append(names, course.Course.Name)
+	}
+	// The error channel is read once the courses are done.
+	if err := <-errs; err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(strings.Join(names, ", "))
+	// Output: Go desde cero, Go Avanzado, Docker
+}
+
+func ExampleStatusError() {
+	client, done := exampleClient()
+	defer done()
+	ctx := context.Background()
+
+	_, err := client.Subscriptions.List(ctx, "expired-token")
+	if errors.Is(err, edteam.ErrUnauthorized) {
+		fmt.Println("log in again")
+	}
+	var statusErr *edteam.StatusError
+	if errors.As(err, &statusErr) {
+		fmt.Println(statusErr.Code, statusErr.Message())
+	}
+	// Output:
+	// log in again
+	// 401 Unauthorized: the token expired
+}
+
+func ExampleUnavailableError() {
+	client, done := exampleClient()
+	defer done()
+
+	// The gifts endpoint isn't in the public docs of EDteam, the stand-in
+	// doesn't have it either.
+	_, err := client.Billing.Gift(context.Background(), "token-of-ana", 1, "friend@example.com")
+	switch {
+	case errors.Is(err, edteam.ErrEndpointUnavailable):
+		fmt.Println("buy the gift on the website")
+	case err != nil:
+		log.Fatal(err)
+	}
+	// Output: buy the gift on the website
+}
@@ -0,0 +1,54 @@
+package edteam_test
+
+import (
+	"context"
+	"testing"
+
+	"edteam-mcp/pkg/edteam"
+	"edteam-mcp/testsupport"
+)
+
+// newFakeClient returns a client of a fake EDteam answering with the
+// fixtures of testsupport.
+func newFakeClient(t *testing.T, opts ...edteam.Option) (*edteam.Client, *testsupport.FakeEDteam) {
+	t.Helper()
+
+	fake := testsupport.NewFakeEDteam(t)
+	client, err := edteam.New(append([]edteam.Option{edteam.WithBaseURL(fake.URL)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, fake
+}
+
+// TestFixturesGolden decodes every fixture through the client, the golden
+// files show what the models keep of each response.
+func TestFixturesGolden(t *testing.T) {
+	client, _ := newFakeClient(t)
+	ctx := context.Background()
+
+	token, err := client.Login(ctx, "student@example.com", "secret")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	testsupport.AssertGoldenJSON(t, "login", token)
+
+	courses, err := client.Courses.List(ctx, 1, 10)
+	if err != nil {
+		t.Fatalf("Courses.List() error = %v", err)
+	}
+	testsupport.AssertGoldenJSON(t, "courses", courses)
+
+	subscriptions, err := client.Subscriptions.List(ctx, token)
+	if err != nil {
+		t.Fatalf("Subscriptions.List() error = %v", err)
+	}
+	testsupport.AssertGoldenJSON(t, "subscriptions", subscriptions)
+
+	cart, err := client.Cart.Add(ctx, token, 101)
+	if err != nil {
+		t.Fatalf("Cart.Add() error = %v", err)
+	}
+	testsupport.AssertGoldenJSON(t, "shopping_cart", cart)
+}
@@ -0,0 +1,71 @@
+package edteam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func FuzzCoursesQuery(f *testing.F) {
+	f.Add(uint(1), uint(50))
+	f.Add(uint(0), uint(10))
+	f.Add(^uint(0), ^uint(0))
+	f.Fuzz(func(t *testing.T, page, limit uint) {
+		body, err := coursesQuery(page, limit)
+		if page == 0 || limit == 0 {
+			if err == nil {
+				t.Errorf("coursesQuery(%d, %d) error = nil, want an error", page, limit)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("coursesQuery(%d, %d) error = %v", page, limit, err)
+		}
+
+		var query map[string]string
+		if err := json.Unmarshal(body, &query); err != nil || len(query) != 1 {
+			t.Fatalf("coursesQuery(%d, %d) = %s, want a JSON object with the name only", page, limit, body)
+		}
+		if want := fmt.Sprintf("cache:GENERAL:page(%d):limit(%d):key(COURSES_GRID_PAGINATION)", page, limit); query["name"] != want {
+			t.Errorf("coursesQuery(%d, %d) name = %q, want %q", page, limit, query["name"], want)
+		}
+	})
+}
+
+func FuzzIsJSON(f *testing.F) {
+	f.Add("application/json", []byte(`{"data":[]}`))
+	f.Add("", []byte(`[1,2]`))
+	f.Add("text/plain; charset=utf-8", []byte(`{"data":`))
+	f.Add("text/html", []byte(`<html></html>`))
+	f.Add("application/problem+json", []byte(`{}`))
+	f.Add("application/json; charset=\"", []byte(` {} `))
+	f.Fuzz(func(t *testing.T, contentType string, body []byte) {
+		// Without a content type the body decides, it must be valid JSON.
+		if isJSON("", body) && !json.Valid(body) {
+			t.Errorf("isJSON(\"\", %q) = true for invalid JSON", body)
+		}
+		isJSON(contentType, body)
+	})
+}
+
+func FuzzDecode(f *testing.F) {
+	f.Add([]byte(`{"data":[{"course":{"id":101,"slug":"go-avanzado"}}]}`))
+	f.Add([]byte(`{"data":[{"course":{"created_at":"not a date"}}]}`))
+	f.Add([]byte(`{"data":null}`))
+	f.Add([]byte(" \n"))
+	f.Add([]byte(`{"data":[{"course_prices":[{"price":1e400}]}]}`))
+	client, err := New()
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Fuzz(func(t *testing.T, body []byte) {
+		var courses CourseResponse
+		if err := client.Decode(context.Background(), 200, body, &courses); err != nil {
+			return
+		}
+		if !json.Valid(body) {
+			t.Errorf("Decode(%q) accepted invalid JSON", body)
+		}
+	})
+}
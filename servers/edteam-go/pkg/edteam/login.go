@@ -0,0 +1,33 @@
+package edteam
+
+import (
+	"context"
+	"net/http"
+)
+
+const urlLogin = "https://api.ed.team/api/v1/login"
+
+// Login returns the token of the account, sent to the endpoints of the
+// account until EDteam answers 401.
+func (c *Client) Login(ctx context.Context, email, password string, opts ...CallOption) (string, error) {
+	login := Login{
+		Email:    email,
+		Password: password,
+	}
+
+	statusCode, responseBody, err := c.Send(ctx, true, http.MethodPost, urlLogin, "", login, opts...)
+	if err != nil {
+		return "", err
+	}
+	if statusCode != http.StatusOK {
+		return "", NewStatusError(statusCode, responseBody)
+	}
+	// Parse the response
+	var response LoginResponse
+	err = c.Decode(ctx, statusCode, responseBody, &response)
+	if err != nil {
+		return "", err
+	}
+
+	return response.Data.Token, nil
+}
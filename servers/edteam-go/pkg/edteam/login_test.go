@@ -0,0 +1,58 @@
+package edteam_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"edteam-mcp/pkg/edteam"
+	"edteam-mcp/testsupport"
+)
+
+func TestLogin(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	token, err := client.Login(context.Background(), "student@example.com", "secret")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if token != testsupport.Token {
+		t.Errorf("Login() = %q, want %q", token, testsupport.Token)
+	}
+
+	requests := fake.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("Login() sent %d requests, want 1", len(requests))
+	}
+	var login edteam.Login
+	if err := json.Unmarshal(requests[0].Body, &login); err != nil {
+		t.Fatalf("the login body isn't JSON: %v", err)
+	}
+	if login.Email != "student@example.com" || login.Password != "secret" {
+		t.Errorf("the login body = %+v, want the email and the password", login)
+	}
+	if got := requests[0].Header.Get("Authorization"); got != "" {
+		t.Errorf("Login() sent Authorization %q, want none", got)
+	}
+}
+
+func TestLoginRejected(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.RespondWith(http.MethodPost, "/api/v1/login", http.StatusUnauthorized,
+		[]byte(`{"messages":[{"title":"Error","message":"invalid credentials","code":"E001"}]}`))
+
+	_, err := client.Login(context.Background(), "student@example.com", "wrong")
+	if !errors.Is(err, edteam.ErrUnauthorized) {
+		t.Fatalf("Login() error = %v, want ErrUnauthorized", err)
+	}
+	var statusErr *edteam.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Message() != "Error: invalid credentials" {
+		t.Errorf("Login() error = %v, want the message of EDteam", err)
+	}
+	// A 401 isn't transient, it is sent once.
+	if got := len(fake.Requests()); got != 1 {
+		t.Errorf("Login() sent %d requests, want 1", got)
+	}
+}
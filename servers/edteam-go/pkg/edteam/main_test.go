@@ -0,0 +1,13 @@
+package edteam_test
+
+import (
+	"testing"
+
+	"go.uber.org/goleak"
+)
+
+// TestMain fails the tests that leave goroutines behind, like a stream
+// still fetching pages after its context was cancelled.
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
@@ -0,0 +1,353 @@
+package edteam
+
+type Subscription struct {
+	ID               int     `json:"id"`
+	SubscriptionDate Time    `json:"subscription_date"`
+	Months           int     `json:"months"`
+	BeginsAt         Time    `json:"begins_at"`
+	EndsAt           Time    `json:"ends_at"`
+	State            string  `json:"state"`
+	Observations     *string `json:"observations,omitempty"`
+	CreatedAt        Time    `json:"created_at"`
+	Buyer            string  `json:"buyer"`
+}
+
+type SubscriptionResponse struct {
+	Data []Subscription `json:"data"`
+}
+
+// Login is the body of the login request.
+type Login struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type LoginResponse struct {
+	Data LoginData `json:"data"`
+}
+
+type LoginData struct {
+	Token string `json:"token"`
+}
+
+type CourseResponse struct {
+	Data []Course `json:"data"`
+}
+
+// Course is an item of the catalog: the course with its prices and
+// professors.
+type Course struct {
+	Course       CourseDetails `json:"course"`
+	CoursePrices []CoursePrice `json:"course_prices"`
+	Professors   []Professor   `json:"professors"`
+}
+
+type CourseDetails struct {
+	AddressedTo     string `json:"addressed_to,omitempty"`
+	CourseType      string `json:"course_type"`
+	CreatedAt       Time   `json:"created_at"`
+	ID              int    `json:"id"`
+	Level           string `json:"level"`
+	Name            string `json:"name"`
+	OnSale          bool   `json:"on_sale"`
+	Picture         string `json:"picture,omitempty"`
+	Slug            string `json:"slug"`
+	Subtitle        string `json:"subtitle,omitempty"`
+	VerticalPicture string `json:"vertical_picture,omitempty"`
+	Visible         bool   `json:"visible"`
+	YouLearn        string `json:"you_learn,omitempty"`
+}
+
+type CoursePrice struct {
+	BasePrice  int  `json:"base_price"`
+	CreatedAt  Time `json:"created_at"`
+	CurrencyId int  `json:"currency_id"`
+	ID         int  `json:"id"`
+	Price      int  `json:"price"`
+}
+
+type Professor struct {
+	Biography   string `json:"biography,omitempty"`
+	City        string `json:"city,omitempty"`
+	CountryName string `json:"country_name,omitempty"`
+	CreatedAt   Time   `json:"created_at"`
+	Firstname   string `json:"firstname"`
+	ID          int    `json:"id"`
+	Lastname    string `json:"lastname"`
+	Nickname    string `json:"nickname,omitempty"`
+	Picture     string `json:"picture,omitempty"`
+}
+
+type ShoppingCartResponse struct {
+	Messages []Message
+}
+
+type GiftResponse struct {
+	Messages []Message
+}
+
+type ProfileResponse struct {
+	Data Profile `json:"data"`
+}
+
+type Profile struct {
+	ID        int    `json:"id"`
+	Firstname string `json:"firstname"`
+	Lastname  string `json:"lastname"`
+	Nickname  string `json:"nickname,omitempty"`
+	Email     string `json:"email"`
+}
+
+type TeamMembersResponse struct {
+	Data []TeamMember `json:"data"`
+}
+
+type TeamMember struct {
+	ID        int        `json:"id"`
+	Firstname string     `json:"firstname"`
+	Lastname  string     `json:"lastname"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	Seats     []TeamSeat `json:"seats"`
+}
+
+type TeamSeat struct {
+	CourseID   int    `json:"course_id"`
+	CourseName string `json:"course_name"`
+}
+
+type SeatResponse struct {
+	Messages []Message
+}
+
+type MemberProgressResponse struct {
+	Data []MemberProgress `json:"data"`
+}
+
+type MemberProgress struct {
+	CourseID      int     `json:"course_id"`
+	CourseName    string  `json:"course_name"`
+	Progress      float64 `json:"progress"`
+	LastWatchedAt Time    `json:"last_watched_at"`
+}
+
+type ReferralResponse struct {
+	Data Referral `json:"data"`
+}
+
+type Referral struct {
+	Code  string        `json:"code"`
+	URL   string        `json:"url"`
+	Stats ReferralStats `json:"stats"`
+}
+
+type ReferralStats struct {
+	Clicks    int     `json:"clicks"`
+	Signups   int     `json:"signups"`
+	Purchases int     `json:"purchases"`
+	Earnings  float64 `json:"earnings"`
+}
+
+type BillingAddress struct {
+	Name       string `json:"name"`
+	TaxID      string `json:"tax_id"`
+	Address    string `json:"address"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
+type BillingAddressResponse struct {
+	Data BillingAddress `json:"data"`
+}
+
+type PaymentMethodsResponse struct {
+	Data []StoredPaymentMethod `json:"data"`
+}
+
+// StoredPaymentMethod is a payment method saved in the account. EDteam only
+// sends the last four digits of the cards.
+type StoredPaymentMethod struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Brand    string `json:"brand,omitempty"`
+	Last4    string `json:"last4,omitempty"`
+	Expires  string `json:"expires,omitempty"`
+	Default  bool   `json:"default"`
+	Provider string `json:"provider"`
+}
+
+type FAQResponse struct {
+	Data []FAQ `json:"data"`
+}
+
+type FAQ struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+type TrailerResponse struct {
+	Data Trailer `json:"data"`
+}
+
+type Trailer struct {
+	URL      string `json:"url"`
+	Duration int    `json:"duration"`
+}
+
+type ThreadAuthor struct {
+	Firstname string `json:"firstname"`
+	Lastname  string `json:"lastname"`
+	Nickname  string `json:"nickname,omitempty"`
+}
+
+type ThreadsResponse struct {
+	Data []ThreadSummary `json:"data"`
+}
+
+// ThreadSummary is a thread in the list of a course, without its body and
+// answers.
+type ThreadSummary struct {
+	ID        int          `json:"id"`
+	Title     string       `json:"title"`
+	Author    ThreadAuthor `json:"author"`
+	Answers   int          `json:"answers"`
+	Solved    bool         `json:"solved"`
+	CreatedAt Time         `json:"created_at"`
+}
+
+type ThreadResponse struct {
+	Data Thread `json:"data"`
+}
+
+type Thread struct {
+	ID        int            `json:"id"`
+	Title     string         `json:"title"`
+	Body      string         `json:"body"`
+	Author    ThreadAuthor   `json:"author"`
+	Solved    bool           `json:"solved"`
+	CreatedAt Time           `json:"created_at"`
+	Answers   []ThreadAnswer `json:"answers"`
+}
+
+type ThreadAnswer struct {
+	ID        int          `json:"id"`
+	Body      string       `json:"body"`
+	Author    ThreadAuthor `json:"author"`
+	Accepted  bool         `json:"accepted"`
+	CreatedAt Time         `json:"created_at"`
+}
+
+type ReviewResponse struct {
+	Data     Review    `json:"data"`
+	Messages []Message `json:"messages"`
+}
+
+type Review struct {
+	ID        int    `json:"id"`
+	Rating    int    `json:"rating"`
+	Text      string `json:"text"`
+	CreatedAt Time   `json:"created_at"`
+}
+
+type SupportTicketResponse struct {
+	Data     CreatedRequest `json:"data"`
+	Messages []Message      `json:"messages"`
+}
+
+type PrivacyRequestResponse struct {
+	Data     CreatedRequest `json:"data"`
+	Messages []Message      `json:"messages"`
+}
+
+// CreatedRequest is a request filed with EDteam, like a support ticket or
+// a privacy request, and its status.
+type CreatedRequest struct {
+	ID        int    `json:"id"`
+	Status    string `json:"status"`
+	CreatedAt Time   `json:"created_at"`
+}
+
+type LiveEventsResponse struct {
+	Data []LiveEvent `json:"data"`
+}
+
+type LiveEvent struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	StartsAt    Time   `json:"starts_at"`
+	Duration    int    `json:"duration"`
+	URL         string `json:"url"`
+}
+
+type BlogPostsResponse struct {
+	Data []BlogPost `json:"data"`
+}
+
+type BlogPost struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Slug        string `json:"slug"`
+	Summary     string `json:"summary,omitempty"`
+	PublishedAt Time   `json:"published_at"`
+}
+
+type LastWatchedResponse struct {
+	Data LastWatched `json:"data"`
+}
+
+type LastWatched struct {
+	Course    WatchedItem `json:"course"`
+	Class     WatchedItem `json:"class"`
+	Progress  float64     `json:"progress"`
+	WatchedAt Time        `json:"watched_at"`
+}
+
+type OwnedCoursesResponse struct {
+	Data []OwnedCourse `json:"data"`
+}
+
+// OwnedCourse is a course bought individually, the account keeps it even
+// without a subscription.
+type OwnedCourse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// WatchedItem is the course or the class of LastWatched.
+type WatchedItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type CurriculumResponse struct {
+	Data []CurriculumModule `json:"data"`
+}
+
+type CurriculumModule struct {
+	ID      int               `json:"id"`
+	Name    string            `json:"name"`
+	Classes []CurriculumClass `json:"classes"`
+}
+
+type CurriculumClass struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	Duration int    `json:"duration"`
+	Free     bool   `json:"free"`
+}
+
+// SupportTicket is a help request for EDteam support, Category is one of
+// the Ticket constants.
+type SupportTicket struct {
+	Category    string `json:"category"`
+	Subject     string `json:"subject"`
+	Description string `json:"description"`
+	OrderID     string `json:"order_id,omitempty"`
+	CourseID    int    `json:"course_id,omitempty"`
+}
@@ -0,0 +1,134 @@
+package edteam
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultUserAgent is the User-Agent of the requests when WithUserAgent isn't
+// given.
+const DefaultUserAgent = "edteam-go"
+
+// Option configures a Client built with New.
+type Option func(*Client) error
+
+// WithBaseURL sends the requests to the EDteam hosts to baseURL instead,
+// keeping their paths after the path of baseURL, like to a fake EDteam in
+// the tests or to a proxy. EDteam splits its API over several hosts, all of
+// them go to baseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(baseURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid base URL %q, use a URL like http://localhost:8080", baseURL)
+		}
+		u.Path = strings.TrimSuffix(u.Path, "/")
+		c.baseURL = u
+		return nil
+	}
+}
+
+// WithHTTPClient sends the requests with httpClient instead of
+// http.DefaultClient, to set its transport or timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+		return nil
+	}
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) error {
+		c.Retry = policy
+		return nil
+	}
+}
+
+// WithMaxResponseSize fails the responses longer than limit bytes with a
+// *ResponseTooLargeError instead of DefaultMaxResponseSize, zero reads them
+// whole whatever their size.
+func WithMaxResponseSize(limit int64) Option {
+	return func(c *Client) error {
+		if limit < 0 {
+			return fmt.Errorf("invalid max response size %d, use 0 or greater", limit)
+		}
+		c.maxResponseSize = limit
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent of the requests, so EDteam can tell the
+// apps using the client apart.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithLogger logs the retries and the failures that don't reach the caller
+// with logger instead of slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) error {
+		if logger != nil {
+			c.logger = logger
+		}
+		return nil
+	}
+}
+
+// IsHost reports whether host is ed.team or one of its subdomains.
+func IsHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	return host == "ed.team" || strings.HasSuffix(host, ".ed.team")
+}
+
+// resolve returns rawURL on the base URL when it is an EDteam URL.
+func (c *Client) resolve(rawURL string) string {
+	if c.baseURL == nil {
+		return rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || !IsHost(u.Hostname()) {
+		return rawURL
+	}
+
+	u.Scheme = c.baseURL.Scheme
+	u.Host = c.baseURL.Host
+	u.Path = c.baseURL.Path + u.Path
+	if u.RawPath != "" {
+		u.RawPath = c.baseURL.EscapedPath() + u.RawPath
+	}
+
+	return u.String()
+}
+
+// CallOption changes the headers of a single request, it is the last
+// argument of the methods of the client and their services:
+//
+//	courses, err := client.Courses.List(ctx, 1, 10,
+//		edteam.WithLocale("en"),
+//		edteam.WithHeader("X-Request-ID", requestID),
+//	)
+type CallOption func(header http.Header)
+
+// WithHeader sets a header of the request, replacing the one set by the
+// client, like a trace header or a currency preference.
+func WithHeader(key, value string) CallOption {
+	return func(header http.Header) {
+		header.Set(key, value)
+	}
+}
+
+// WithLocale asks EDteam to answer in locale, like es or en. It overrides the
+// language of WithLanguage for this request.
+func WithLocale(locale string) CallOption {
+	return WithHeader("Accept-Language", locale)
+}
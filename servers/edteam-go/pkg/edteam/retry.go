@@ -0,0 +1,135 @@
+package edteam
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RetryPolicy is how many times a request is sent, how long to wait between
+// the attempts and which failures are retried.
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt, 1 disables the retries.
+	MaxAttempts int
+	// BaseDelay doubles with every attempt up to MaxDelay, the wait is a
+	// random delay up to it.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// RetryableStatus are the status codes retried, nil uses
+	// DefaultRetryableStatus. Only 429 is retried for the requests that
+	// aren't idempotent, EDteam refused them without processing them.
+	RetryableStatus []int
+	// Methods override the policy for some HTTP methods, like a single
+	// attempt for POST. Their own Methods are ignored.
+	Methods map[string]RetryPolicy
+}
+
+// DefaultRetryableStatus are the status codes of the transient failures of
+// EDteam and its proxies.
+var DefaultRetryableStatus = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+// forMethod returns the policy of the requests with method.
+func (p RetryPolicy) forMethod(method string) RetryPolicy {
+	if override, ok := p.Methods[strings.ToUpper(method)]; ok {
+		return override
+	}
+
+	return p
+}
+
+// wait sleeps the backoff of attempt unless ctx is done first.
+func (p RetryPolicy) wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(p.backoff(attempt))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// backoff returns an exponential delay with full jitter.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return time.Duration(rand.Int64N(int64(delay) + 1))
+}
+
+func (p RetryPolicy) shouldRetry(statusCode int, err error, idempotent bool) bool {
+	if err == nil {
+		return p.retryableStatus(statusCode, idempotent)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var nonJSONErr *NonJSONError
+	if errors.As(err, &nonJSONErr) {
+		return p.retryableStatus(nonJSONErr.StatusCode, idempotent)
+	}
+	if notSent(err) {
+		return true
+	}
+
+	var netErr net.Error
+	return idempotent && errors.As(err, &netErr)
+}
+
+func (p RetryPolicy) retryableStatus(statusCode int, idempotent bool) bool {
+	if !idempotent && statusCode != http.StatusTooManyRequests {
+		return false
+	}
+	statuses := p.RetryableStatus
+	if statuses == nil {
+		statuses = DefaultRetryableStatus
+	}
+
+	return slices.Contains(statuses, statusCode)
+}
+
+// notSent reports whether err happened before the request was written, when
+// the connection to EDteam couldn't be opened.
+func notSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// Temporary reports whether sending the request again later may succeed.
+func Temporary(err error) bool {
+	var ambiguousErr *AmbiguousError
+	if errors.As(err, &ambiguousErr) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return DefaultRetryPolicy.retryableStatus(statusErr.Code, true)
+	}
+
+	return DefaultRetryPolicy.shouldRetry(0, err, true)
+}
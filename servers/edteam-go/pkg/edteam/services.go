@@ -0,0 +1,74 @@
+package edteam
+
+import "context"
+
+// The mocks of edteammock are generated with moq, install it with
+// go install github.com/matryer/moq@latest.
+//go:generate moq -rm -pkg edteammock -out edteammock/edteammock.go . CoursesAPI SubscriptionsAPI CartAPI AccountAPI BillingAPI BusinessAPI CommunityAPI ContentAPI
+
+// CoursesAPI reads the public catalog and the pages of the courses,
+// CoursesService is its implementation.
+type CoursesAPI interface {
+	List(ctx context.Context, page, limit uint, opts ...CallOption) (CourseResponse, error)
+	Curriculum(ctx context.Context, slug string, opts ...CallOption) (CurriculumResponse, error)
+	FAQ(ctx context.Context, slug string, opts ...CallOption) (FAQResponse, error)
+	Trailer(ctx context.Context, slug string, opts ...CallOption) (TrailerResponse, error)
+}
+
+// SubscriptionsAPI reads the subscriptions of an account,
+// SubscriptionsService is its implementation.
+type SubscriptionsAPI interface {
+	List(ctx context.Context, token string, opts ...CallOption) (SubscriptionResponse, error)
+}
+
+// CartAPI changes the shopping cart of an account, CartService is its
+// implementation.
+type CartAPI interface {
+	Add(ctx context.Context, token string, courseID int, opts ...CallOption) (ShoppingCartResponse, error)
+}
+
+// AccountAPI reads and changes an account, AccountService is its
+// implementation.
+type AccountAPI interface {
+	Profile(ctx context.Context, token string, opts ...CallOption) (ProfileResponse, error)
+	Referral(ctx context.Context, token string, opts ...CallOption) (ReferralResponse, error)
+	LastWatched(ctx context.Context, token string, opts ...CallOption) (LastWatchedResponse, bool, error)
+	OwnedCourses(ctx context.Context, token string, opts ...CallOption) (OwnedCoursesResponse, error)
+	RequestDataExport(ctx context.Context, token string, opts ...CallOption) (PrivacyRequestResponse, error)
+	RequestDeletion(ctx context.Context, token string, opts ...CallOption) (PrivacyRequestResponse, error)
+	CreateSupportTicket(ctx context.Context, token string, ticket SupportTicket, opts ...CallOption) (SupportTicketResponse, error)
+}
+
+// BillingAPI reads the billing details of an account and buys gifts,
+// BillingService is its implementation.
+type BillingAPI interface {
+	Address(ctx context.Context, token string, opts ...CallOption) (BillingAddressResponse, error)
+	UpdateAddress(ctx context.Context, token string, address BillingAddress, opts ...CallOption) (BillingAddressResponse, error)
+	PaymentMethods(ctx context.Context, token string, opts ...CallOption) (PaymentMethodsResponse, error)
+	Gift(ctx context.Context, token string, courseID int, email string, opts ...CallOption) (GiftResponse, error)
+}
+
+// BusinessAPI manages the team of a business plan, BusinessService is its
+// implementation.
+type BusinessAPI interface {
+	Members(ctx context.Context, token string, opts ...CallOption) (TeamMembersResponse, error)
+	AssignSeat(ctx context.Context, token string, memberID, courseID int, opts ...CallOption) (SeatResponse, error)
+	RevokeSeat(ctx context.Context, token string, memberID, courseID int, opts ...CallOption) (SeatResponse, error)
+	MemberProgress(ctx context.Context, token string, memberID int, opts ...CallOption) (MemberProgressResponse, error)
+}
+
+// CommunityAPI reads and publishes the threads and reviews of the courses,
+// CommunityService is its implementation.
+type CommunityAPI interface {
+	Threads(ctx context.Context, token string, courseID, page, limit int, opts ...CallOption) (ThreadsResponse, error)
+	Thread(ctx context.Context, token string, threadID int, opts ...CallOption) (ThreadResponse, error)
+	PostQuestion(ctx context.Context, token string, courseID int, title, question string, opts ...CallOption) (ThreadResponse, error)
+	SubmitReview(ctx context.Context, token string, courseID, rating int, text string, opts ...CallOption) (ReviewResponse, error)
+}
+
+// ContentAPI reads the live classes and the blog, ContentService is its
+// implementation.
+type ContentAPI interface {
+	LiveEvents(ctx context.Context, opts ...CallOption) (LiveEventsResponse, error)
+	BlogPosts(ctx context.Context, opts ...CallOption) (BlogPostsResponse, error)
+}
@@ -0,0 +1,33 @@
+package edteam
+
+import (
+	"context"
+	"net/http"
+)
+
+const urlSubscriptions = "https://api.ed.team/api/v1/subscriptions/historical"
+
+// SubscriptionsService reads the subscriptions of the account of the token.
+type SubscriptionsService struct {
+	client *Client
+}
+
+// List returns every subscription in the history of the account, the
+// expired ones included.
+func (s *SubscriptionsService) List(ctx context.Context, token string, opts ...CallOption) (SubscriptionResponse, error) {
+	statusCode, responseBody, err := s.client.Send(ctx, true, http.MethodGet, urlSubscriptions, token, nil, opts...)
+	if err != nil {
+		return SubscriptionResponse{}, err
+	}
+	if statusCode != http.StatusOK {
+		return SubscriptionResponse{}, NewStatusError(statusCode, responseBody)
+	}
+	// Parse the response
+	var subscriptions SubscriptionResponse
+	err = s.client.Decode(ctx, statusCode, responseBody, &subscriptions)
+	if err != nil {
+		return SubscriptionResponse{}, err
+	}
+
+	return subscriptions, nil
+}
@@ -0,0 +1,34 @@
+package edteam_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"edteam-mcp/pkg/edteam"
+	"edteam-mcp/testsupport"
+)
+
+func TestSubscriptionsList(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	subscriptions, err := client.Subscriptions.List(context.Background(), testsupport.Token)
+	if err != nil {
+		t.Fatalf("Subscriptions.List() error = %v", err)
+	}
+	if len(subscriptions.Data) != 2 || subscriptions.Data[1].State != "active" {
+		t.Errorf("Subscriptions.List() = %+v, want the subscriptions of the fixture", subscriptions.Data)
+	}
+	if got := fake.Requests()[0].Header.Get("Authorization"); got != "Bearer "+testsupport.Token {
+		t.Errorf("Subscriptions.List() sent Authorization %q, want the token", got)
+	}
+}
+
+func TestSubscriptionsListExpiredToken(t *testing.T) {
+	client, _ := newFakeClient(t)
+
+	_, err := client.Subscriptions.List(context.Background(), "expired-token")
+	if !errors.Is(err, edteam.ErrUnauthorized) {
+		t.Errorf("Subscriptions.List() error = %v, want ErrUnauthorized", err)
+	}
+}
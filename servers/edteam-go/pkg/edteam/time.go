@@ -0,0 +1,69 @@
+package edteam
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts are the formats of the timestamps returned by the endpoints of
+// EDteam, they don't agree on one. The layouts without a zone are in UTC.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// Time is a timestamp of EDteam. It accepts RFC 3339 with or without the
+// zone, the MySQL format, dates and Unix seconds or milliseconds. null, an
+// empty string, the zero date of MySQL or a timestamp in none of the formats
+// are decoded as the zero time instead of failing the whole response, so
+// one odd record doesn't hide the rest.
+type Time struct {
+	time.Time
+}
+
+// NewTime returns t as a Time.
+func NewTime(t time.Time) Time {
+	return Time{Time: t}
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	t.Time = parseTime(bytes.TrimSpace(data))
+	return nil
+}
+
+func parseTime(data []byte) time.Time {
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return time.Time{}
+	}
+
+	if data[0] != '"' {
+		seconds, err := strconv.ParseFloat(string(data), 64)
+		if err != nil || seconds <= 0 {
+			return time.Time{}
+		}
+		// The milliseconds of JavaScript are more than 10^11, a date past
+		// the year 5000 in seconds.
+		if seconds > 1e11 {
+			return time.UnixMilli(int64(seconds)).UTC()
+		}
+		return time.Unix(int64(seconds), 0).UTC()
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil || value == "" || strings.HasPrefix(value, "0000-00-00") {
+		return time.Time{}
+	}
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// SpendingCap is the most the assistant may spend for an account, the zero
+// value means no cap.
+type SpendingCap struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+func (c SpendingCap) String() string {
+	if c.Amount == 0 {
+		return ""
+	}
+
+	return strconv.FormatFloat(c.Amount, 'f', -1, 64) + " " + c.Currency
+}
+
+// parseSpendingCap parses an amount and an ISO 4217 currency like "100 USD".
+func parseSpendingCap(value string) (SpendingCap, error) {
+	if strings.TrimSpace(value) == "" {
+		return SpendingCap{}, nil
+	}
+
+	amount, currency, ok := strings.Cut(strings.TrimSpace(value), " ")
+	currency = strings.TrimSpace(currency)
+	parsed, err := strconv.ParseFloat(amount, 64)
+	if !ok || err != nil || parsed <= 0 || math.IsNaN(parsed) || math.IsInf(parsed, 0) || !currencyPattern.MatchString(currency) {
+		return SpendingCap{}, fmt.Errorf("POLICY_SPENDING_CAP must be an amount greater than 0 and an ISO 4217 code like 100 USD, got %q", value)
+	}
+
+	return SpendingCap{Amount: parsed, Currency: strings.ToUpper(currency)}, nil
+}
+
+// SpendingCapError is returned when buying a course would take the account
+// over the spending cap.
+type SpendingCapError struct {
+	Cap   SpendingCap
+	Spent float64
+	Price float64
+}
+
+func (e *SpendingCapError) Error() string {
+	return fmt.Sprintf("the spending cap of the account is %s, %s %s were already spent and the course costs %s %s",
+		e.Cap, strconv.FormatFloat(e.Spent, 'f', -1, 64), e.Cap.Currency, strconv.FormatFloat(e.Price, 'f', -1, 64), e.Cap.Currency)
+}
+
+// Spending adds up the prices of the courses added to the cart or gifted by
+// every caller, to hold them to the spending cap. It outlives the tools and
+// the client sessions, so neither reloading the config nor reconnecting
+// resets it.
+type Spending struct {
+	mu    sync.Mutex
+	spent map[string]float64
+}
+
+func NewSpending() *Spending {
+	return &Spending{spent: make(map[string]float64)}
+}
+
+// Reserve adds price to what the caller key spent unless it goes over
+// limit. The returned release takes it back, for the purchases that failed.
+func (s *Spending) Reserve(key string, price float64, limit SpendingCap) (release func(), err error) {
+	if limit.Amount == 0 {
+		return func() {}, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	spent := s.spent[key]
+	if spent+price > limit.Amount {
+		return nil, &SpendingCapError{Cap: limit, Spent: spent, Price: price}
+	}
+	s.spent[key] = spent + price
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.spent[key] -= price
+	}, nil
+}
+
+// priceIn returns the price of course in currency, converting it with rates
+// when EDteam doesn't sell the course in that currency.
+func priceIn(course Course, currency string, codes map[int]string, rates Rates) (float64, error) {
+	for _, price := range course.CoursePrices {
+		if codes[price.CurrencyId] == currency {
+			return float64(price.Price), nil
+		}
+	}
+	for _, price := range course.CoursePrices {
+		if converted, err := rates.Convert(float64(price.Price), codes[price.CurrencyId], currency); err == nil {
+			return converted, nil
+		}
+	}
+
+	return 0, fmt.Errorf("the course %d has no price in %s, set CURRENCY_RATES to convert it", course.Course.ID, currency)
+}
+
+// parseToolPatterns parses a list of tool names or patterns like Shopping-*.
+func parseToolPatterns(name string, patterns []string) ([]string, error) {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%s has an invalid tool pattern %q: %w", name, pattern, err)
+		}
+	}
+
+	return patterns, nil
+}
+
+// Policy are the constraints set by the operator of the server, for the
+// agent frameworks that read and obey them.
+type Policy struct {
+	// SpendingCap is the most to spend for an account, like the courses
+	// gifted or added to the cart. It is missing when there is no cap.
+	SpendingCap *SpendingCap `json:"spending_cap,omitempty"`
+	// ConfirmTools are the exposed tools that ask the user before running.
+	ConfirmTools  []string `json:"confirm_tools"`
+	ReadOnly      bool     `json:"read_only"`
+	DataRetention string   `json:"data_retention"`
+}
+
+func policy(cfg Config, tools map[string]*server.ServerTool, locale Locale) Policy {
+	result := Policy{ConfirmTools: []string{}, ReadOnly: cfg.ReadOnly, DataRetention: dataRetention(cfg, locale)}
+	if cfg.SpendingCap.Amount > 0 {
+		spendingCap := cfg.SpendingCap
+		result.SpendingCap = &spendingCap
+	}
+	for name, tool := range tools {
+		_, confirms := tool.Tool.InputSchema.Properties["confirm"]
+		if confirms {
+			result.ConfirmTools = append(result.ConfirmTools, name)
+		}
+	}
+	sort.Strings(result.ConfirmTools)
+
+	return result
+}
+
+// dataRetention is POLICY_DATA_RETENTION or, when it isn't set, what the
+// server keeps in DATA_DIR.
+func dataRetention(cfg Config, locale Locale) string {
+	if cfg.DataRetention != "" {
+		return cfg.DataRetention
+	}
+
+	return locale.T("policy_data_retention", cfg.DataDir)
+}
+
+// setInstructions builds the server instructions for cfg. The clients that
+// connect from then on get them, the ones already connected keep theirs
+// until they connect again and can call Policy for the current rules.
+func (srv *Server) setInstructions(cfg Config) {
+	instructions := policyInstructions(cfg, cfg.Locale)
+	srv.instructions.Store(&instructions)
+}
+
+// sendInstructions is an initialize hook: mcp-go only takes the instructions
+// when the server is created, so they are replaced on every result with the
+// ones of the current config.
+func (srv *Server) sendInstructions(ctx context.Context, id any, request *mcp.InitializeRequest, result *mcp.InitializeResult) {
+	if instructions := srv.instructions.Load(); instructions != nil {
+		result.Instructions = *instructions
+	}
+}
+
+// policyInstructions are the server instructions sent to the clients when
+// they connect, the policy in a few sentences.
+func policyInstructions(cfg Config, locale Locale) string {
+	instructions := []string{locale.T("policy_instructions")}
+	if cfg.SpendingCap.Amount > 0 {
+		instructions = append(instructions, locale.T("policy_spending_cap", cfg.SpendingCap))
+	}
+	if cfg.ConfirmSideEffects {
+		instructions = append(instructions, locale.T("policy_confirm"))
+	}
+	if len(cfg.ConfirmTools) > 0 {
+		instructions = append(instructions, locale.T("policy_confirm_tools", strings.Join(cfg.ConfirmTools, ", ")))
+	}
+	if cfg.ReadOnly {
+		instructions = append(instructions, locale.T("policy_read_only"))
+	}
+	instructions = append(instructions, dataRetention(cfg, locale))
+
+	return strings.Join(instructions, " ")
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func FuzzParseSpendingCap(f *testing.F) {
+	for _, seed := range []string{"100 USD", " 49.99 pen ", "NaN USD", "Inf EUR", "-5 USD", "100", "1e400 USD", ""} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		spendingCap, err := parseSpendingCap(value)
+		if err != nil || spendingCap == (SpendingCap{}) {
+			return
+		}
+		if spendingCap.Amount <= 0 || math.IsInf(spendingCap.Amount, 0) || !currencyPattern.MatchString(spendingCap.Currency) {
+			t.Errorf("parseSpendingCap(%q) = %+v", value, spendingCap)
+		}
+	})
+}
+
+func TestReleaseFailedKeepsAmbiguousPurchases(t *testing.T) {
+	limit := SpendingCap{Amount: 100, Currency: "USD"}
+	tests := []struct {
+		name      string
+		err       error
+		wantSpent float64
+	}{
+		{name: "rejected purchase", err: &StatusError{Code: 500}, wantSpent: 0},
+		{name: "ambiguous purchase", err: &AmbiguousError{Err: errors.New("timeout")}, wantSpent: 60},
+		{name: "wrapped ambiguous purchase", err: fmt.Errorf("add to cart: %w", &AmbiguousError{Err: errors.New("reset")}), wantSpent: 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spending := NewSpending()
+			release, err := spending.Reserve("server", 60, limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			releaseFailed(release, tt.err)
+			if spent := spending.spent["server"]; spent != tt.wantSpent {
+				t.Errorf("spent %v, want %v", spent, tt.wantSpent)
+			}
+		})
+	}
+}
+
+func TestInstructionsFollowTheReloadedConfig(t *testing.T) {
+	srv := &Server{}
+	initialize := func() string {
+		var result mcp.InitializeResult
+		srv.sendInstructions(context.Background(), 1, &mcp.InitializeRequest{}, &result)
+		return result.Instructions
+	}
+
+	cfg := Config{Locale: LocaleEN}
+	srv.setInstructions(cfg)
+	if got := initialize(); got == "" || strings.Contains(got, "50 USD") {
+		t.Fatalf("instructions = %q, want the policy without a spending cap", got)
+	}
+
+	cfg.SpendingCap = SpendingCap{Amount: 50, Currency: "USD"}
+	srv.setInstructions(cfg)
+	if got := initialize(); !strings.Contains(got, "50 USD") {
+		t.Errorf("instructions after the reload = %q, want the spending cap of 50 USD", got)
+	}
+}
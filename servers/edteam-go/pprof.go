@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"time"
+)
+
+// checkLoopback fails unless addr listens on localhost only, the profiles
+// show the memory of the process, tokens included.
+func checkLoopback(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid --debug-pprof address %q, use one like localhost:6060", addr)
+	}
+	if !isLoopback(host) {
+		return fmt.Errorf("--debug-pprof must listen on localhost, got %q", addr)
+	}
+
+	return nil
+}
+
+// isLoopback reports whether host only accepts connections from this
+// machine. An empty host listens on every interface.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+// servePprof serves the net/http/pprof profiles on addr until ctx is done.
+// It returns once it listens, so a port in use fails the start up.
+func servePprof(ctx context.Context, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for --debug-pprof: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	httpServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		httpServer.Close()
+	}()
+	go func() {
+		slog.Warn("serving the pprof profiles", "url", "http://"+listener.Addr().String()+"/debug/pprof/")
+		if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("the pprof server stopped", "error", err)
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+
+	"edteam-mcp/pkg/edteam"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// getTrailerAndCurriculum fetches the trailer and the curriculum of the
+// course at the same time, the first one that fails cancels the other.
+func getTrailerAndCurriculum(ctx context.Context, api edteam.CoursesAPI, slug string) (TrailerResponse, CurriculumResponse, error) {
+	var trailer TrailerResponse
+	var curriculum CurriculumResponse
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		trailer, err = api.Trailer(ctx, slug)
+		return err
+	})
+	g.Go(func() (err error) {
+		curriculum, err = api.Curriculum(ctx, slug)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return TrailerResponse{}, CurriculumResponse{}, err
+	}
+
+	return trailer, curriculum, nil
+}
+
+// coursePreview joins the trailer with the classes of the curriculum that
+// can be watched for free.
+func coursePreview(courseID int, name, slug string, trailer TrailerResponse, curriculum CurriculumResponse) CoursePreview {
+	preview := CoursePreview{
+		CourseID:    courseID,
+		CourseName:  name,
+		TrailerURL:  trailer.Data.URL,
+		FreeClasses: []PreviewClass{},
+	}
+	for _, section := range curriculum.Data {
+		for _, class := range section.Classes {
+			if !class.Free {
+				continue
+			}
+			preview.FreeClasses = append(preview.FreeClasses, PreviewClass{
+				ID:   class.ID,
+				Name: class.Name,
+				URL:  classURL(slug, class.Slug),
+			})
+		}
+	}
+
+	return preview
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// PriceHistory stores the prices seen in the catalog. A price is only stored
+// when it differs from the last one seen for the course and currency, so the
+// database grows with the price changes and not with every catalog fetch.
+type PriceHistory struct {
+	db *sql.DB
+}
+
+type PricePoint struct {
+	Currency   string    `json:"currency"`
+	Price      int       `json:"price"`
+	BasePrice  int       `json:"base_price"`
+	OnSale     bool      `json:"on_sale"`
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+type PriceSummary struct {
+	CourseID int          `json:"course_id"`
+	Name     string       `json:"name"`
+	Current  *PricePoint  `json:"current,omitempty"`
+	Lowest   *PricePoint  `json:"lowest,omitempty"`
+	History  []PricePoint `json:"history"`
+	Message  string       `json:"message"`
+}
+
+func OpenPriceHistory(path string) (*PriceHistory, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer, one connection avoids busy errors.
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS prices (
+		course_id   INTEGER NOT NULL,
+		currency_id INTEGER NOT NULL,
+		price       INTEGER NOT NULL,
+		base_price  INTEGER NOT NULL,
+		observed_at TIMESTAMP NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS prices_course ON prices (course_id, currency_id, observed_at)`)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create the price history in %s: %w", path, err)
+	}
+
+	return &PriceHistory{db: db}, nil
+}
+
+func (h *PriceHistory) Close() error {
+	return h.db.Close()
+}
+
+// Record stores the prices of the catalog that changed since the last time
+// they were seen.
+func (h *PriceHistory) Record(ctx context.Context, courses CourseResponse, now time.Time) error {
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	last, err := tx.PrepareContext(ctx, `SELECT price, base_price FROM prices
+		WHERE course_id = ? AND currency_id = ? ORDER BY observed_at DESC LIMIT 1`)
+	if err != nil {
+		return err
+	}
+	defer last.Close()
+	insert, err := tx.PrepareContext(ctx, `INSERT INTO prices (course_id, currency_id, price, base_price, observed_at) VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	for _, item := range courses.Data {
+		for _, price := range item.CoursePrices {
+			var lastPrice, lastBase int
+			err := last.QueryRowContext(ctx, item.Course.ID, price.CurrencyId).Scan(&lastPrice, &lastBase)
+			if err == nil && lastPrice == price.Price && lastBase == price.BasePrice {
+				continue
+			}
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+			if _, err := insert.ExecContext(ctx, item.Course.ID, price.CurrencyId, price.Price, price.BasePrice, now.UTC()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// History returns the price changes of the course, oldest first.
+func (h *PriceHistory) History(ctx context.Context, courseID int, codes map[int]string) ([]PricePoint, error) {
+	rows, err := h.db.QueryContext(ctx, `SELECT currency_id, price, base_price, observed_at FROM prices
+		WHERE course_id = ? ORDER BY observed_at, currency_id`, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	points := []PricePoint{}
+	for rows.Next() {
+		var currencyID int
+		var point PricePoint
+		if err := rows.Scan(&currencyID, &point.Price, &point.BasePrice, &point.ObservedAt); err != nil {
+			return nil, err
+		}
+		point.Currency = codes[currencyID]
+		if point.Currency == "" {
+			point.Currency = fmt.Sprintf("%d", currencyID)
+		}
+		point.OnSale = point.Price < point.BasePrice
+		points = append(points, point)
+	}
+
+	return points, rows.Err()
+}
+
+// recordPrices is the catalog observer that keeps the history up to date.
+// Failing to store the prices doesn't fail the tool that fetched the catalog.
+func recordPrices(history *PriceHistory) func(context.Context, CourseResponse) {
+	return func(ctx context.Context, courses CourseResponse) {
+		if err := history.Record(ctx, courses, time.Now()); err != nil {
+			log.Printf("failed to record the catalog prices: %v", err)
+		}
+	}
+}
+
+// priceSummary finds the current and lowest price in currency of the
+// history.
+func priceSummary(courseID int, name string, history []PricePoint, currency string, locale Locale) PriceSummary {
+	summary := PriceSummary{CourseID: courseID, Name: name, History: []PricePoint{}}
+	for _, point := range history {
+		if currency != "" && point.Currency != currency {
+			continue
+		}
+		summary.History = append(summary.History, point)
+		summary.Current = &point
+		if summary.Lowest == nil || point.Price < summary.Lowest.Price {
+			summary.Lowest = &point
+		}
+	}
+
+	switch {
+	case summary.Current == nil:
+		summary.Message = locale.T("price_history_empty")
+	case summary.Current.Price <= summary.Lowest.Price:
+		summary.Message = locale.T("price_history_lowest", len(summary.History))
+	default:
+		summary.Message = locale.T("price_history_higher", summary.Lowest.Price, summary.Lowest.Currency)
+	}
+
+	return summary
+}
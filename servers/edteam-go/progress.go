@@ -0,0 +1,12 @@
+package main
+
+import "fmt"
+
+// classURL is the deep link that opens the class in the EDteam player.
+func classURL(courseSlug, classSlug string) string {
+	if classSlug == "" {
+		return fmt.Sprintf("https://ed.team/cursos/%s", courseSlug)
+	}
+
+	return fmt.Sprintf("https://ed.team/cursos/%s/clases/%s", courseSlug, classSlug)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// justifyPurchasePrompt frames a business case for buying a course with the
+// live data of EDteam: the details of the course and the subscription
+// history of the account. Its slug argument is completed by Completions.
+func justifyPurchasePrompt(client *edteam.Client, catalog *Catalog, session *Session, locale Locale) (mcp.Prompt, server.PromptHandlerFunc) {
+	prompt := mcp.NewPrompt(
+		"justify_purchase",
+		mcp.WithPromptDescription("A business case to justify buying a course of EDteam, built from the details of the course and your subscription history"),
+		mcp.WithArgument("slug", mcp.ArgumentDescription("Slug of the course to buy, e.g. go-avanzado"), mcp.RequiredArgument()),
+		mcp.WithArgument("goal", mcp.ArgumentDescription("What the purchase is for, e.g. migrating our backend to Go")),
+	)
+
+	return prompt, func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		slug := strings.TrimSpace(request.Params.Arguments["slug"])
+		goal := strings.TrimSpace(request.Params.Arguments["goal"])
+		if slug == "" {
+			return nil, errors.New(`the argument "slug" is required`)
+		}
+		ctx = WithLocale(ctx, locale)
+
+		courses, err := catalog.Courses(ctx)
+		if err != nil {
+			return nil, err
+		}
+		detail := courseDetails(ctx, client.Courses, courses, nil, []string{slug}, time.Now(), locale)[0]
+		if detail.Course == nil {
+			return nil, errors.New(detail.Error.Message)
+		}
+		if detail.Error != nil {
+			// The case can be made without the curriculum.
+			slog.Warn("failed to get the curriculum of the course", "slug", slug, "error", detail.Error.Message)
+			detail.Error = nil
+		}
+
+		var subscriptions SubscriptionResponse
+		err = session.Do(ctx, func(token string) (err error) {
+			subscriptions, err = client.Subscriptions.List(ctx, token)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		view := newSubscriptionsView(subscriptions)
+		humanizeSubscriptions(&view, time.Now(), locale)
+
+		course, err := json.MarshalIndent(detail, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		history, err := json.MarshalIndent(view.Data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if goal != "" {
+			goal = locale.T("justify_purchase_goal", goal)
+		}
+		text := locale.T("justify_purchase", detail.Course.Course.Name, goal, course, history)
+
+		return mcp.NewGetPromptResult(
+			locale.T("justify_purchase_description", detail.Course.Course.Name),
+			[]mcp.PromptMessage{mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text))},
+		), nil
+	}
+}
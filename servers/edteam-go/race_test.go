@@ -0,0 +1,6 @@
+//go:build race
+
+package main
+
+// raceEnabled tells the tests the race detector is on, it allocates.
+const raceEnabled = true
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// defaultRateLimits keep a runaway agent from filling the cart or sending
+// gifts in a loop. TOOL_RATE_LIMITS replaces them.
+const defaultRateLimits = "Shopping-Cart-Add-Course=3/m,Gift-Course=3/m"
+
+// RateLimit allows Calls calls of the tools matching Pattern in every
+// window of Per. A long window, like 24h, works as a quota.
+type RateLimit struct {
+	Pattern string
+	Calls   int
+	Per     time.Duration
+}
+
+func (l RateLimit) String() string {
+	return fmt.Sprintf("%s=%d/%s", l.Pattern, l.Calls, l.Per)
+}
+
+// RateLimitError is returned when a tool was called more times than its
+// limit allows.
+type RateLimitError struct {
+	Tool       string
+	Limit      RateLimit
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("the tool %s can be called %d times every %s, retry in %s", e.Tool, e.Limit.Calls, e.Limit.Per, e.RetryAfter.Round(time.Second))
+}
+
+// parseRateLimits parses a comma separated list like
+// "Shopping-*=3/m,Courses-List=30/1m,Gift-Course=5/24h". The window takes
+// s, m, h and d as a shortcut for one second, minute, hour and day.
+func parseRateLimits(value string) ([]RateLimit, error) {
+	var limits []RateLimit
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		pattern, rate, ok := strings.Cut(item, "=")
+		count, window, okRate := strings.Cut(rate, "/")
+		if !ok || !okRate || strings.TrimSpace(pattern) == "" {
+			return nil, fmt.Errorf("invalid rate limit %q, use Tool=calls/window like Courses-List=30/m", item)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid tool pattern %q: %w", pattern, err)
+		}
+		calls, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil || calls < 1 {
+			return nil, fmt.Errorf("invalid rate limit %q, the calls must be a number greater than 0", item)
+		}
+		per, err := parseWindow(strings.TrimSpace(window))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit %q: %w", item, err)
+		}
+
+		limits = append(limits, RateLimit{Pattern: strings.TrimSpace(pattern), Calls: calls, Per: per})
+	}
+
+	return limits, nil
+}
+
+func parseWindow(window string) (time.Duration, error) {
+	switch window {
+	case "s":
+		return time.Second, nil
+	case "m":
+		return time.Minute, nil
+	case "h":
+		return time.Hour, nil
+	case "d":
+		return 24 * time.Hour, nil
+	}
+
+	per, err := time.ParseDuration(window)
+	if err != nil || per <= 0 {
+		return 0, fmt.Errorf("the window must be s, m, h, d or a duration like 10m, got %q", window)
+	}
+
+	return per, nil
+}
+
+// callHistorySweep is how often the callers that stopped calling are
+// forgotten.
+const callHistorySweep = time.Minute
+
+// CallHistory remembers when every tool was called by every caller. It
+// outlives the middlewares, reloading the config doesn't reset the limits.
+type CallHistory struct {
+	mu      sync.Mutex
+	entries map[string]*callEntry
+	sweptAt time.Time
+}
+
+// callEntry are the calls of a caller within the window of its limit.
+type callEntry struct {
+	per   time.Duration
+	calls []time.Time
+}
+
+func NewCallHistory() *CallHistory {
+	return &CallHistory{entries: make(map[string]*callEntry)}
+}
+
+// check reports whether key can call once more at now, without recording
+// the call. When it can't it returns how long until the oldest call leaves
+// the window.
+func (h *CallHistory) check(key string, limit RateLimit, now time.Time) (bool, time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.within(key, limit, now)
+}
+
+// allow records a call of key at now unless limit was already reached, then
+// it returns how long until the oldest call leaves the window.
+func (h *CallHistory) allow(key string, limit RateLimit, now time.Time) (bool, time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ok, retryAfter := h.within(key, limit, now)
+	if !ok {
+		return false, retryAfter
+	}
+	entry := h.entries[key]
+	if entry == nil {
+		entry = &callEntry{per: limit.Per}
+		h.entries[key] = entry
+	}
+	entry.calls = append(entry.calls, now)
+
+	return true, 0
+}
+
+// within drops the calls of key that left the window and compares the rest
+// with limit. It also forgets the callers without calls in their window,
+// once every callHistorySweep.
+func (h *CallHistory) within(key string, limit RateLimit, now time.Time) (bool, time.Duration) {
+	if now.Sub(h.sweptAt) >= callHistorySweep {
+		for k, entry := range h.entries {
+			if len(entry.calls) == 0 || !entry.calls[len(entry.calls)-1].After(now.Add(-entry.per)) {
+				delete(h.entries, k)
+			}
+		}
+		h.sweptAt = now
+	}
+
+	entry := h.entries[key]
+	if entry == nil {
+		return true, 0
+	}
+	start := now.Add(-limit.Per)
+	i := 0
+	for i < len(entry.calls) && !entry.calls[i].After(start) {
+		i++
+	}
+	entry.calls = entry.calls[i:]
+
+	if len(entry.calls) >= limit.Calls {
+		return false, entry.calls[0].Sub(start)
+	}
+
+	return true, 0
+}
+
+// rateLimit enforces the first limit matching the tool, counting the calls
+// of every caller apart in history. It returns two middlewares: check goes
+// before the confirmation, so the user isn't asked to approve a call that
+// would be rejected, and record after it, so a call the user declined
+// isn't counted.
+func rateLimit(history *CallHistory, locale Locale, limits []RateLimit) (check, record ToolMiddleware) {
+	limitOf := func(tool mcp.Tool) RateLimit {
+		for _, candidate := range limits {
+			if matchesTool([]string{candidate.Pattern}, tool.Name) {
+				return candidate
+			}
+		}
+		return RateLimit{}
+	}
+	enforce := func(count func(key string, limit RateLimit, now time.Time) (bool, time.Duration)) ToolMiddleware {
+		return func(tool mcp.Tool, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+			limit := limitOf(tool)
+			if limit.Calls == 0 {
+				return next
+			}
+
+			return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				key := limit.String() + " " + callerKey(ctx)
+				if ok, retryAfter := count(key, limit, time.Now()); !ok {
+					err := &RateLimitError{Tool: tool.Name, Limit: limit, RetryAfter: retryAfter}
+					return toolErrorResult(err, NewArgs(request.GetArguments()).Locale(locale)), nil
+				}
+
+				return next(ctx, request)
+			}
+		}
+	}
+
+	return enforce(history.check), enforce(history.allow)
+}
+
+// callerKey tells apart the callers that share the limits. The clients of a
+// multi-tenant server are the accounts of their tokens, hashed so the
+// tokens aren't kept in memory. The rest act as the account of the server
+// with the same API key, so they share its limits: counting every client
+// session apart would let a client reset its limits by reconnecting.
+func callerKey(ctx context.Context) string {
+	if token, ok := sessionToken(ctx); ok {
+		return "tenant " + tenantKey(token)
+	}
+
+	return "server"
+}
+
+func clientSessionID(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+
+	return ""
+}
+
+// retryAfterSeconds rounds up, so retrying after it always succeeds.
+func retryAfterSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"path"
+	"testing"
+)
+
+func FuzzParseRateLimits(f *testing.F) {
+	for _, seed := range []string{"Shopping-*=3/m,Courses-List=30/1m", "Gift-Course=5/24h", "[=1/s", "A=0/m", "A=1/-1s", ",,", ""} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		limits, err := parseRateLimits(value)
+		if err != nil {
+			return
+		}
+		for _, limit := range limits {
+			if limit.Pattern == "" || limit.Calls < 1 || limit.Per <= 0 {
+				t.Errorf("parseRateLimits(%q) returned %+v", value, limit)
+			}
+			if _, err := path.Match(limit.Pattern, "Courses-List"); err != nil {
+				t.Errorf("parseRateLimits(%q) returned the invalid pattern %q", value, limit.Pattern)
+			}
+		}
+	})
+}
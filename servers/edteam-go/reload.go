@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"reflect"
+	"time"
+)
+
+// configPollInterval is how often the config file is checked for changes.
+const configPollInterval = 2 * time.Second
+
+// watchConfig reloads the config file when it changes and calls apply with
+// the new config once it is valid. Only reloadedSettings are copied onto the
+// running config; the login, the caches and the transport keep their values
+// until the server restarts.
+//
+// fileKeys are the variables set from the file at startup. They are unset
+// before every reload, so a variable removed from the file goes back to its
+// default, while the ones set in the real environment keep winning. A value
+// on reload forces a reload even if the file looks unchanged.
+func watchConfig(ctx context.Context, flags Flags, current Config, fileKeys []string, reload <-chan struct{}, apply func(Config) error) {
+	path := flags.ConfigFile
+	last, err := os.Stat(path)
+	if err != nil {
+		slog.Error("failed to watch the config file", "path", path, "err", err)
+		return
+	}
+
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+	for {
+		forced := false
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-reload:
+			forced = true
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			slog.Warn("failed to check the config file", "path", path, "err", err)
+			continue
+		}
+		if !forced && info.ModTime().Equal(last.ModTime()) && info.Size() == last.Size() {
+			continue
+		}
+		last = info
+
+		next, keys, err := reloadConfig(flags, fileKeys)
+		fileKeys = keys
+		if err != nil {
+			slog.Error("invalid config file, keeping the current config", "path", path, "err", err)
+			continue
+		}
+
+		applied := withReloadedSettings(current, next)
+		if err := apply(applied); err != nil {
+			slog.Error("invalid config file, keeping the current config", "path", path, "err", err)
+			continue
+		}
+		if changed := restartRequired(current, next); len(changed) > 0 {
+			slog.Warn("some settings of the config file only change after a restart", "path", path, "settings", changed)
+		}
+		current = applied
+		slog.Info("config file reloaded", "path", path)
+	}
+}
+
+func reloadConfig(flags Flags, fileKeys []string) (Config, []string, error) {
+	for _, key := range fileKeys {
+		os.Unsetenv(key)
+	}
+	keys, err := loadEnvFile(flags.ConfigFile)
+	if err != nil {
+		return Config{}, keys, err
+	}
+	cfg, err := LoadConfig()
+	var problems ConfigError
+	problems.add(err)
+	problems.add(flags.apply(&cfg))
+	if err := problems.err(); err != nil {
+		return Config{}, keys, err
+	}
+
+	return cfg, keys, nil
+}
+
+// reloadedSettings are the fields of Config applied without a restart: the
+// log level and the ones read by the tools, which are built again on reload.
+// The rest are read once at startup, like the transport, the HTTP pool, the
+// login or the caches. EMAIL isn't one: the session stays logged in with the
+// account it started with.
+var reloadedSettings = map[string]bool{
+	"LogLevel":           true,
+	"Locale":             true,
+	"ToolLocale":         true,
+	"DefaultPageSize":    true,
+	"MaxPageSize":        true,
+	"CurrencyCodes":      true,
+	"ToolTimeout":        true,
+	"ResultCacheTTL":     true,
+	"ResultCacheTTLs":    true,
+	"RateLimits":         true,
+	"ConfirmSideEffects": true,
+	"ConfirmTools":       true,
+	"SpendingCap":        true,
+	"DataRetention":      true,
+	"DriftWarnings":      true,
+	"EnabledTools":       true,
+	"DisabledTools":      true,
+	"ReadOnly":           true,
+}
+
+// restartRequired returns the settings changed by next that are only read
+// at startup.
+func restartRequired(current, next Config) []string {
+	var changed []string
+	a, b := reflect.ValueOf(current), reflect.ValueOf(next)
+	for i := range a.NumField() {
+		name := a.Type().Field(i).Name
+		if !reloadedSettings[name] && !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			changed = append(changed, name)
+		}
+	}
+
+	return changed
+}
+
+// withReloadedSettings returns current with the reloadedSettings of next, the
+// other fields keep the values the server started with.
+func withReloadedSettings(current, next Config) Config {
+	a, b := reflect.ValueOf(&current).Elem(), reflect.ValueOf(next)
+	for i := range a.NumField() {
+		if reloadedSettings[a.Type().Field(i).Name] {
+			a.Field(i).Set(b.Field(i))
+		}
+	}
+
+	return current
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWithReloadedSettings(t *testing.T) {
+	current := Config{Email: "ana@example.com", MultiTenant: false, MaxPageSize: 100, ReadOnly: false}
+	next := Config{Email: "bob@example.com", MultiTenant: true, MaxPageSize: 50, ReadOnly: true}
+
+	applied := withReloadedSettings(current, next)
+	if applied.Email != current.Email {
+		t.Errorf("Email = %q, the session is still logged in as %q", applied.Email, current.Email)
+	}
+	if applied.MultiTenant {
+		t.Error("MultiTenant was applied without a restart")
+	}
+	if applied.MaxPageSize != 50 || !applied.ReadOnly {
+		t.Errorf("the reloaded settings weren't applied: %+v", applied)
+	}
+
+	changed := restartRequired(current, next)
+	if !slices.Contains(changed, "Email") || !slices.Contains(changed, "MultiTenant") {
+		t.Errorf("restartRequired() = %v, want Email and MultiTenant", changed)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// courseResourcePrefix is the URI of a course resource without the slug.
+	courseResourcePrefix = "edteam://courses/"
+
+	subscriptionsCSVResource  = "edteam://me/subscriptions.csv"
+	subscriptionsJSONResource = "edteam://me/subscriptions.json"
+)
+
+// courseResource is the template of the courses of the catalog as resources,
+// its slug is completed by Completions.
+func courseResource(catalog *Catalog) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	template := mcp.NewResourceTemplate(
+		courseResourcePrefix+"{slug}",
+		"Course",
+		mcp.WithTemplateDescription("A course of the EDteam catalog with its prices and professors"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	return template, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		slug := strings.TrimPrefix(request.Params.URI, courseResourcePrefix)
+		courses, err := catalog.Courses(ctx)
+		if err != nil {
+			return nil, err
+		}
+		i := courseSlugIndex(courses, slug)
+		if i < 0 {
+			return nil, fmt.Errorf("course %q was not found in the catalog", slug)
+		}
+
+		data, err := json.MarshalIndent(courses.Data[i], "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		return []mcp.ResourceContents{mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		}}, nil
+	}
+}
+
+// subscriptionResources are the subscription history as CSV and JSON, so a
+// client can attach or save it without the model copying it. They are
+// generated from EDteam on every read.
+func subscriptionResources(client *edteam.Client, session *Session, locale Locale) []server.ServerResource {
+	read := func(ctx context.Context) (SubscriptionsView, error) {
+		var subscriptions SubscriptionResponse
+		err := session.Do(ctx, func(token string) (err error) {
+			subscriptions, err = client.Subscriptions.List(WithLocale(ctx, locale), token)
+			return err
+		})
+		if err != nil {
+			return SubscriptionsView{}, err
+		}
+		view := newSubscriptionsView(subscriptions)
+		humanizeSubscriptions(&view, time.Now(), locale)
+
+		return view, nil
+	}
+
+	csvResource := mcp.NewResource(
+		subscriptionsCSVResource,
+		"Subscriptions (CSV)",
+		mcp.WithResourceDescription("Your subscription history in EDteam, ready to open in a spreadsheet"),
+		mcp.WithMIMEType("text/csv"),
+	)
+	jsonResource := mcp.NewResource(
+		subscriptionsJSONResource,
+		"Subscriptions (JSON)",
+		mcp.WithResourceDescription("Your subscription history in EDteam"),
+		mcp.WithMIMEType("application/json"),
+	)
+
+	return []server.ServerResource{
+		{Resource: csvResource, Handler: func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			subscriptions, err := read(ctx)
+			if err != nil {
+				return nil, err
+			}
+			text, err := subscriptionsCSV(subscriptions)
+			if err != nil {
+				return nil, err
+			}
+
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "text/csv", Text: text}}, nil
+		}},
+		{Resource: jsonResource, Handler: func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			subscriptions, err := read(ctx)
+			if err != nil {
+				return nil, err
+			}
+			data, err := json.MarshalIndent(subscriptions.Data, "", "  ")
+			if err != nil {
+				return nil, err
+			}
+
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "application/json", Text: string(data)}}, nil
+		}},
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// defaultResultCacheTTLs turn the cache off for Whats-New, which answers
+// what changed since the previous call, for Catalog-Diff, which compares with
+// the live catalog, and for the jobs, which change while they run.
+// RESULT_CACHE_TTLS replaces them.
+const defaultResultCacheTTLs = "Whats-New=0,Catalog-Diff=0,Job-*=0"
+
+// maxCachedResults bounds the memory used by the cache.
+const maxCachedResults = 1000
+
+// ToolTTL is how long the results of the tools matching Pattern are cached,
+// zero doesn't cache them.
+type ToolTTL struct {
+	Pattern string
+	TTL     time.Duration
+}
+
+// parseToolTTLs parses a comma separated list like "Courses-List=10m,Subscriptions=0".
+func parseToolTTLs(value string) ([]ToolTTL, error) {
+	var ttls []ToolTTL
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		pattern, duration, ok := strings.Cut(item, "=")
+		if !ok || strings.TrimSpace(pattern) == "" {
+			return nil, fmt.Errorf("invalid cache TTL %q, use Tool=duration like Courses-List=10m", item)
+		}
+		ttl, err := time.ParseDuration(strings.TrimSpace(duration))
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("invalid cache TTL %q, the duration must be like 10m or 0", item)
+		}
+
+		ttls = append(ttls, ToolTTL{Pattern: strings.TrimSpace(pattern), TTL: ttl})
+	}
+
+	return ttls, nil
+}
+
+type cachedResult struct {
+	result  *mcp.CallToolResult
+	expires time.Time
+}
+
+// ResultCache keeps the results of the read-only tools by tool, arguments
+// and client session.
+type ResultCache struct {
+	mu      sync.Mutex
+	results map[string]cachedResult
+}
+
+func (c *ResultCache) get(key string, now time.Time) (*mcp.CallToolResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.results[key]
+	if !ok || now.After(cached.expires) {
+		return nil, false
+	}
+
+	return cached.result, true
+}
+
+func (c *ResultCache) put(key string, result *mcp.CallToolResult, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.results) >= maxCachedResults {
+		now := time.Now()
+		for k, cached := range c.results {
+			if now.After(cached.expires) {
+				delete(c.results, k)
+			}
+		}
+		for k := range c.results {
+			if len(c.results) < maxCachedResults {
+				break
+			}
+			delete(c.results, k)
+		}
+	}
+	c.results[key] = cachedResult{result: result, expires: expires}
+}
+
+type skipCacheKey struct{}
+
+// skipResultCache keeps the result of the call of ctx out of the cache, for
+// the handlers whose result is stale as soon as it is returned, like the
+// start of a job.
+func skipResultCache(ctx context.Context) {
+	if skip, ok := ctx.Value(skipCacheKey{}).(*bool); ok {
+		*skip = true
+	}
+}
+
+// cacheResults answers the repeated calls of the read-only tools from the
+// cache. The TTL of a tool is the first of ttls matching it, or fallback.
+// Error results and the calls marked with skipResultCache are never cached.
+// The cache starts empty when the config is
+// reloaded.
+func cacheResults(fallback time.Duration, ttls []ToolTTL) ToolMiddleware {
+	cache := &ResultCache{results: make(map[string]cachedResult)}
+
+	return func(tool mcp.Tool, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		ttl := fallback
+		for _, candidate := range ttls {
+			if matchesTool([]string{candidate.Pattern}, tool.Name) {
+				ttl = candidate.TTL
+				break
+			}
+		}
+		if hint := tool.Annotations.ReadOnlyHint; ttl <= 0 || hint == nil || !*hint {
+			return next
+		}
+
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// Marshalling a map sorts the keys, equal arguments give equal keys.
+			args, err := json.Marshal(request.GetArguments())
+			if err != nil {
+				return next(ctx, request)
+			}
+			key := clientSessionID(ctx) + " " + tool.Name + " " + string(args)
+
+			now := time.Now()
+			if result, ok := cache.get(key, now); ok {
+				copied := *result
+				copied.Content = append([]mcp.Content(nil), result.Content...)
+				return &copied, nil
+			}
+
+			skip := false
+			result, err := next(context.WithValue(ctx, skipCacheKey{}, &skip), request)
+			if err == nil && result != nil && !result.IsError && !skip {
+				cache.put(key, result, now.Add(ttl))
+			}
+
+			return result, err
+		}
+	}
+}
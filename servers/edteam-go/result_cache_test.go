@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func FuzzParseToolTTLs(f *testing.F) {
+	for _, seed := range []string{"Courses-List=10m,Subscriptions=0", "*=1h", "A=-1s", "=5m", "A", ""} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		ttls, err := parseToolTTLs(value)
+		if err != nil {
+			return
+		}
+		for _, ttl := range ttls {
+			if ttl.Pattern == "" || ttl.TTL < 0 {
+				t.Errorf("parseToolTTLs(%q) returned %+v", value, ttl)
+			}
+		}
+	})
+}
+
+// BenchmarkCacheKey calls a cached tool, the key of the arguments is built
+// on every call, hit or miss.
+func BenchmarkCacheKey(b *testing.B) {
+	readOnly := true
+	tool := mcp.Tool{Name: "Courses-List"}
+	tool.Annotations.ReadOnlyHint = &readOnly
+	handler := cacheResults(time.Minute, nil)(tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("page"), nil
+	})
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"page": 2.0, "limit": 10.0, "currency": "PEN", "fields": []any{"id", "name"}}
+	ctx := context.Background()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := handler(ctx, request); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Schedule tells when a task runs next.
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// Every runs a task at a fixed interval.
+type Every time.Duration
+
+func (e Every) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(e))
+}
+
+// Cron is a schedule in the five fields of crontab: minute, hour, day of the
+// month, month and day of the week, in the local time zone.
+type Cron struct {
+	expression string
+
+	minutes, hours, days, months, weekdays uint64
+	// anyDay and anyWeekday are set for *, when both days are restricted a
+	// day matching either runs the task, like in crontab.
+	anyDay, anyWeekday bool
+}
+
+var cronShortcuts = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// ParseCron parses expressions like "0 6 * * *", "*/15 9-18 * * 1-5" or the
+// shortcuts @hourly, @daily, @weekly and @monthly.
+func ParseCron(expression string) (*Cron, error) {
+	expression = strings.TrimSpace(expression)
+	fields := strings.Fields(expression)
+	if shortcut, ok := cronShortcuts[expression]; ok {
+		fields = strings.Fields(shortcut)
+	}
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q, use minute hour day month weekday like 0 6 * * *", expression)
+	}
+
+	c := &Cron{expression: expression, anyDay: fields[2] == "*", anyWeekday: fields[4] == "*"}
+	var err error
+	ranges := []struct {
+		bits     *uint64
+		min, max int
+		name     string
+	}{
+		{&c.minutes, 0, 59, "minute"},
+		{&c.hours, 0, 23, "hour"},
+		{&c.days, 1, 31, "day"},
+		{&c.months, 1, 12, "month"},
+		{&c.weekdays, 0, 7, "weekday"},
+	}
+	for i, r := range ranges {
+		*r.bits, err = parseCronField(fields[i], r.min, r.max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s in cron expression %q: %w", r.name, expression, err)
+		}
+	}
+	// Sunday is both 0 and 7.
+	if c.weekdays&(1<<7) != 0 {
+		c.weekdays |= 1
+	}
+
+	return c, nil
+}
+
+// parseCronField parses a comma separated list of *, numbers and ranges,
+// each with an optional /step.
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		expr, rawStep, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(rawStep)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q", rawStep)
+			}
+			// A longer step matches low only, a huge one would overflow n.
+			if step > max {
+				step = max + 1
+			}
+		}
+
+		low, high := min, max
+		if expr != "*" {
+			rawLow, rawHigh, isRange := strings.Cut(expr, "-")
+			var err error
+			low, err = strconv.Atoi(rawLow)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", expr)
+			}
+			high = low
+			if isRange {
+				high, err = strconv.Atoi(rawHigh)
+				if err != nil {
+					return 0, fmt.Errorf("invalid value %q", expr)
+				}
+			} else if hasStep {
+				high = max
+			}
+		}
+		if low < min || high > max || low > high {
+			return 0, fmt.Errorf("%q is out of the range %d-%d", part, min, max)
+		}
+
+		for n := low; n <= high; n += step {
+			bits |= 1 << n
+		}
+	}
+
+	return bits, nil
+}
+
+func (c *Cron) String() string {
+	return c.expression
+}
+
+// Next returns the first minute after after matching the expression, or the
+// zero time when there is none in the next five years, like for 0 0 30 2 *.
+func (c *Cron) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.months&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case c.hours&(1<<t.Hour()) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case c.minutes&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (c *Cron) matchesDay(t time.Time) bool {
+	day := c.days&(1<<t.Day()) != 0
+	weekday := c.weekdays&(1<<int(t.Weekday())) != 0
+	if !c.anyDay && !c.anyWeekday {
+		return day || weekday
+	}
+
+	return day && weekday
+}
+
+// Task is a job run by the scheduler.
+type Task struct {
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context)
+}
+
+// runSchedule runs every task on its schedule until ctx is done. Every run
+// waits a random jitter first, so several servers don't call EDteam at the
+// same second, and a run is skipped while the previous one is still going.
+func runSchedule(ctx context.Context, tasks []Task, jitter time.Duration) {
+	for _, task := range tasks {
+		go func() {
+			var running atomic.Bool
+			next := task.Schedule.Next(time.Now())
+			for !next.IsZero() {
+				if jitter > 0 {
+					next = next.Add(time.Duration(rand.Int64N(int64(jitter))))
+				}
+				timer := time.NewTimer(time.Until(next))
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+
+				if running.CompareAndSwap(false, true) {
+					go func() {
+						defer running.Store(false)
+						task.Run(ctx)
+					}()
+				} else {
+					slog.Warn("skipping a scheduled task, the previous run is still going", "task", task.Name)
+				}
+				next = task.Schedule.Next(time.Now())
+			}
+			slog.Warn("the schedule of the task never matches again", "task", task.Name)
+		}()
+	}
+}
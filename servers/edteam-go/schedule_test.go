@@ -0,0 +1,23 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func FuzzParseCron(f *testing.F) {
+	for _, seed := range []string{"0 6 * * *", "*/15 9-18 * * 1-5", "0 0 30 2 *", "@daily", "1/9223372036854775807 * * * *", "0 0 * * 7", "60 * * * *", ""} {
+		f.Add(seed)
+	}
+	after := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+	f.Fuzz(func(t *testing.T, expression string) {
+		c, err := ParseCron(expression)
+		if err != nil {
+			return
+		}
+		next := c.Next(after)
+		if !next.IsZero() && (!next.After(after) || next.Second() != 0 || next.After(after.AddDate(5, 0, 0))) {
+			t.Errorf("ParseCron(%q).Next(%v) = %v, want a whole minute in the next five years", expression, after, next)
+		}
+	})
+}
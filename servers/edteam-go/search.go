@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var accents = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+	"Á", "a", "É", "e", "Í", "i", "Ó", "o", "Ú", "u", "Ü", "u", "Ñ", "n",
+)
+
+// normalize lowercases the text and removes the accents so "Álvaro" matches
+// "alvaro".
+func normalize(text string) string {
+	return strings.ToLower(accents.Replace(text))
+}
+
+// filterByProfessor returns the courses with a professor whose first name,
+// last name or nickname contain every word of the query.
+func filterByProfessor(courses CourseResponse, query string) CourseResponse {
+	words := strings.Fields(normalize(query))
+
+	var filtered CourseResponse
+	for _, item := range courses.Data {
+		for _, professor := range item.Professors {
+			name := normalize(strings.Join([]string{professor.Firstname, professor.Lastname, professor.Nickname}, " "))
+			matches := true
+			for _, word := range words {
+				if !strings.Contains(name, word) {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				filtered.Data = append(filtered.Data, item)
+				break
+			}
+		}
+	}
+
+	return filtered
+}
+
+// pageOf returns the given page of the items already loaded in memory. The
+// page is compared with the number of pages before multiplying, so a huge
+// page doesn't overflow the start index.
+func pageOf[T any](items []T, page, limit int) []T {
+	if page < 1 || page-1 >= (len(items)+limit-1)/limit {
+		return items[:0]
+	}
+	start := (page - 1) * limit
+	end := min(start+limit, len(items))
+
+	return items[start:end]
+}
+
+// maxCompletions is the most values a completion can return.
+const maxCompletions = 100
+
+// SearchIndex keeps the catalog normalized for searching, so every keystroke
+// of a completion ranks the courses without normalizing the whole catalog
+// again. It is filled with the catalog the first time it is searched and
+// kept up to date as a catalog observer.
+type SearchIndex struct {
+	load func(ctx context.Context) (CourseResponse, error)
+
+	mu      sync.RWMutex
+	entries []indexEntry
+	loaded  bool
+}
+
+type indexEntry struct {
+	slug  string
+	name  string
+	words []string
+	// text is the normalized subtitle, description and professors.
+	text string
+}
+
+// SearchResult is a course matching a query.
+type SearchResult struct {
+	Slug string
+	Name string
+}
+
+func NewSearchIndex(load func(ctx context.Context) (CourseResponse, error)) *SearchIndex {
+	return &SearchIndex{load: load}
+}
+
+// Update replaces the indexed courses, its signature is the one of a
+// catalog observer.
+func (x *SearchIndex) Update(ctx context.Context, courses CourseResponse) {
+	entries := make([]indexEntry, 0, len(courses.Data))
+	for _, item := range courses.Data {
+		course := item.Course
+		parts := []string{course.Subtitle, course.YouLearn, course.AddressedTo}
+		for _, professor := range item.Professors {
+			parts = append(parts, professor.Firstname, professor.Lastname, professor.Nickname)
+		}
+		entries = append(entries, indexEntry{
+			slug:  course.Slug,
+			name:  course.Name,
+			words: strings.Fields(normalize(course.Name)),
+			text:  normalize(strings.Join(parts, " ")),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.entries = entries
+	x.loaded = true
+}
+
+// Search returns the courses matching every word of query, the best first,
+// and how many matched in total. The last word may be incomplete, as it is
+// while typing. An empty query matches every course, sorted by name.
+func (x *SearchIndex) Search(ctx context.Context, query string, max int) ([]SearchResult, int, error) {
+	x.mu.RLock()
+	loaded := x.loaded
+	x.mu.RUnlock()
+	if !loaded {
+		courses, err := x.load(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		x.Update(ctx, courses)
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	words := strings.FieldsFunc(normalize(query), func(r rune) bool { return r == ' ' || r == '-' })
+	slugPrefix := strings.Join(words, "-")
+	type scored struct {
+		entry *indexEntry
+		score int
+	}
+	var matches []scored
+	for i := range x.entries {
+		entry := &x.entries[i]
+		score := entry.score(words)
+		if slugPrefix != "" && strings.HasPrefix(entry.slug, slugPrefix) {
+			score += 10
+		}
+		if score > 0 || len(words) == 0 {
+			matches = append(matches, scored{entry: entry, score: score})
+		}
+	}
+
+	// The entries are sorted by name, a stable sort keeps ties in that order.
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	results := make([]SearchResult, 0, min(max, len(matches)))
+	for _, match := range matches[:min(max, len(matches))] {
+		results = append(results, SearchResult{Slug: match.entry.slug, Name: match.entry.name})
+	}
+
+	return results, len(matches), nil
+}
+
+// score weighs a word starting a word of the name more than a word found in
+// the rest of the text. It is 0 unless every word is found.
+func (e *indexEntry) score(words []string) int {
+	score := 0
+	for _, word := range words {
+		wordScore := 0
+		for _, nameWord := range e.words {
+			if strings.HasPrefix(nameWord, word) {
+				wordScore = 3
+				break
+			}
+		}
+		if wordScore == 0 && strings.Contains(e.text, word) {
+			wordScore = 1
+		}
+		if wordScore == 0 {
+			return 0
+		}
+		score += wordScore
+	}
+
+	return score
+}
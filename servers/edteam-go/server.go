@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+
+	"edteam-mcp/pkg/edteam"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Server is what the tools share: the client of EDteam, the session of the
+// account and the caches. run builds one for the config, the tools are built
+// again from it when the config file is reloaded.
+type Server struct {
+	mcp    *server.MCPServer
+	client *edteam.Client
+
+	// The session, the rates and the price history are set up once the
+	// config is valid, the tools only use them when they are called.
+	session *Session
+	rates   Rates
+	prices  *PriceHistory
+
+	catalog   *Catalog
+	durations *Durations
+	slots     *CallSlots
+	jobs      *Jobs
+	watchlist *Watchlist
+	spending  *Spending
+	// calls and metrics outlive the middlewares, reloading the config
+	// doesn't reset the rate limits nor the stats.
+	calls    *CallHistory
+	metrics  *Metrics
+	logLevel *slog.LevelVar
+	// instructions are the policy sent to the clients when they connect,
+	// built again when the config is reloaded.
+	instructions atomic.Pointer[string]
+}
+
+// buildTools creates the tools for cfg. It runs again when the config file
+// changes, so the tools pick up the new limits.
+func (srv *Server) buildTools(cfg Config) []server.ServerTool {
+	var tools toolSet
+	srv.accountTools(&tools, cfg)
+	srv.catalogTools(&tools, cfg)
+	srv.updatesTools(&tools, cfg)
+	srv.billingTools(&tools, cfg)
+	srv.teamTools(&tools, cfg)
+	srv.communityTools(&tools, cfg)
+	srv.privacyTools(&tools, cfg)
+	srv.serverTools(&tools, cfg)
+
+	confirm := confirmSideEffects(cfg, srv.mcp, describeCall(srv.catalog, cfg.CurrencyCodes))
+	wrapped := applyMiddlewares(tools, srv.toolMiddlewares(cfg, confirm)...)
+
+	return withConfirmArgument(wrapped, cfg)
+}
+
+// canCall reports whether the session can call EDteam. It is asked on every
+// call, the session is set up after the tools are built.
+func (srv *Server) canCall(ctx context.Context) bool {
+	return srv.session.CanCall(ctx)
+}
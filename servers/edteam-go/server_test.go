@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"edteam-mcp/testsupport"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// serveInProcess runs the server against fake with the account of the
+// fixtures and calls test with a client connected in process. The server
+// stops when test returns.
+func serveInProcess(tb testing.TB, fake *testsupport.FakeEDteam, test func(ctx context.Context, c *client.Client)) {
+	tb.Helper()
+
+	tb.Setenv("EDTEAM_BASE_URL", fake.URL)
+	tb.Setenv("EMAIL", "student@example.com")
+	tb.Setenv("PASSWORD", "secret")
+	tb.Setenv("DATA_DIR", tb.TempDir())
+	tb.Setenv("LOG_LEVEL", "error")
+
+	deps := Deps{Serve: func(s *server.MCPServer, cfg Config, metrics *Metrics) error {
+		c, err := client.NewInProcessClient(s)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx := context.Background()
+		if err := c.Start(ctx); err != nil {
+			return err
+		}
+		initialize := mcp.InitializeRequest{}
+		initialize.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+		if _, err := c.Initialize(ctx, initialize); err != nil {
+			return err
+		}
+
+		test(ctx, c)
+		return nil
+	}}
+	if err := run(context.Background(), Flags{}, deps); err != nil {
+		tb.Fatalf("run() error = %v", err)
+	}
+}
+
+// callTool calls the tool name with args, failing the test when the call
+// can't be made.
+func callTool(tb testing.TB, ctx context.Context, c *client.Client, name string, args map[string]any) *mcp.CallToolResult {
+	tb.Helper()
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = name
+	req.Params.Arguments = args
+	result, err := c.CallTool(ctx, req)
+	if err != nil {
+		tb.Fatalf("CallTool(%s) error = %v", name, err)
+	}
+
+	return result
+}
+
+func BenchmarkCallTool(b *testing.B) {
+	fake := testsupport.NewFakeEDteam(b)
+	serveInProcess(b, fake, func(ctx context.Context, c *client.Client) {
+		// The first call fetches the catalog, the rest are read from it.
+		callTool(b, ctx, c, "Courses-List", nil)
+
+		b.ReportAllocs()
+		for b.Loop() {
+			result := callTool(b, ctx, c, "Courses-List", map[string]any{"limit": 10, "sort": SortName})
+			if result.IsError {
+				b.Fatalf("Courses-List failed: %v", result.Content)
+			}
+		}
+	})
+}
+
+// TestToolCallCancelled gives up on a call while EDteam hasn't answered:
+// the call returns at once and the request to EDteam is cancelled.
+func TestToolCallCancelled(t *testing.T) {
+	fake := testsupport.NewFakeEDteam(t)
+	upstreamCancelled := make(chan struct{})
+	fake.Handle(http.MethodGet, "/api/v1/subscriptions/historical", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(upstreamCancelled)
+	})
+
+	serveInProcess(t, fake, func(ctx context.Context, c *client.Client) {
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		req := mcp.CallToolRequest{}
+		req.Params.Name = "Subscriptions"
+		result, err := c.CallTool(ctx, req)
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("CallTool() returned after %v, want it to stop at the deadline", elapsed)
+		}
+		if err == nil && !result.IsError {
+			t.Errorf("CallTool() = %v, want a cancelled call", result.Content)
+		}
+
+		select {
+		case <-upstreamCancelled:
+		case <-time.After(5 * time.Second):
+			t.Error("the request to EDteam wasn't cancelled")
+		}
+	})
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"edteam-mcp/pkg/edteam"
+)
+
+// TokenHeader is the header the clients of a multi-tenant server send their
+// EDteam token in.
+const TokenHeader = "X-EDteam-Token"
+
+var (
+	// ErrMissingToken means a multi-tenant server got a call without token.
+	ErrMissingToken = errors.New("the session has no EDteam token")
+	// ErrTokenRejected means EDteam rejected the token sent by the session.
+	ErrTokenRejected = errors.New("EDteam rejected the token of the session")
+)
+
+type sessionTokenKey struct{}
+
+// WithSessionToken returns a copy of ctx carrying the EDteam token of the
+// client session, which is used instead of the process-wide token.
+func WithSessionToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, sessionTokenKey{}, token)
+}
+
+func sessionToken(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(sessionTokenKey{}).(string)
+	return token, ok && token != ""
+}
+
+// tokenFromRequest is the context function of the HTTP transports of a
+// multi-tenant server.
+func tokenFromRequest(ctx context.Context, r *http.Request) context.Context {
+	token := strings.TrimSpace(r.Header.Get(TokenHeader))
+	if token == "" {
+		return ctx
+	}
+
+	return WithSessionToken(ctx, token)
+}
+
+// tenantKey identifies the account of a client token without keeping the
+// token, it is its SHA-256.
+func tenantKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Session holds the EDteam token and logs in again when EDteam reports that
+// it expired.
+type Session struct {
+	client   *edteam.Client
+	email    string
+	password string
+	// path is the file the token is persisted in encrypted with cipher,
+	// empty keeps it in memory.
+	path   string
+	cipher *TokenCipher
+
+	tokens *TokenStore
+}
+
+func NewSession(ctx context.Context, client *edteam.Client, email, password string) (*Session, error) {
+	token, err := client.Login(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := NewTokenStore()
+	tokens.Set(email, token)
+
+	return &Session{client: client, email: email, password: password, tokens: tokens}, nil
+}
+
+// RestoreSession reuses the token persisted in path by a previous run, so a
+// restarted daemon doesn't log in again. It logs in when there is no token
+// for email or it can't be decrypted, and every new token is written to
+// path.
+func RestoreSession(ctx context.Context, client *edteam.Client, email, password, path string, cipher *TokenCipher) (*Session, error) {
+	if saved, err := loadToken(path, cipher); err == nil && saved.Email == email && saved.Token != "" {
+		tokens := NewTokenStore()
+		tokens.Set(email, saved.Token)
+		return &Session{client: client, email: email, password: password, path: path, cipher: cipher, tokens: tokens}, nil
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Printf("ignoring the persisted session token: %v", err)
+	}
+
+	s, err := NewSession(ctx, client, email, password)
+	if err != nil {
+		return nil, err
+	}
+	s.path = path
+	s.cipher = cipher
+	s.save(s.Token())
+
+	return s, nil
+}
+
+type persistedToken struct {
+	Email string `json:"email"`
+	Token string `json:"token"`
+}
+
+// loadToken reads the token persisted in path, making the file private to
+// the user when it isn't.
+func loadToken(path string, cipher *TokenCipher) (persistedToken, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return persistedToken{}, err
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		log.Printf("%s was readable by other users, restricting it", path)
+		if err := os.Chmod(path, 0o600); err != nil {
+			return persistedToken{}, err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return persistedToken{}, err
+	}
+	plaintext, err := cipher.Open(data)
+	if err != nil {
+		return persistedToken{}, err
+	}
+
+	var saved persistedToken
+	if err := json.Unmarshal(plaintext, &saved); err != nil {
+		return persistedToken{}, err
+	}
+
+	return saved, nil
+}
+
+// save persists the token encrypted, failing to do it only costs a login on
+// restart. It writes a new file instead of truncating the old one, so the
+// permissions are always the ones set here.
+func (s *Session) save(token string) {
+	if s.path == "" {
+		return
+	}
+	data, err := json.Marshal(persistedToken{Email: s.email, Token: token})
+	if err == nil {
+		data, err = s.cipher.Seal(data)
+	}
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(s.path), 0o700)
+	}
+	tmp := s.path + ".tmp"
+	if err == nil {
+		err = os.WriteFile(tmp, data, 0o600)
+	}
+	if err == nil {
+		err = os.Rename(tmp, s.path)
+	}
+	if err != nil {
+		log.Printf("failed to persist the session token: %v", err)
+	}
+}
+
+// NewTenantSession returns the session of a multi-tenant server, which has no
+// credentials and only calls EDteam with the tokens sent by the clients.
+func NewTenantSession() *Session {
+	return &Session{tokens: NewTokenStore()}
+}
+
+func (s *Session) Token() string {
+	return s.tokens.Get(s.email)
+}
+
+// refresh logs in again unless another call already replaced the expired
+// token.
+func (s *Session) refresh(ctx context.Context, expired string) (string, error) {
+	return s.tokens.Refresh(ctx, s.email, expired, func(ctx context.Context) (string, error) {
+		log.Printf("session expired, re-authenticating")
+		token, err := s.client.Login(ctx, s.email, s.password)
+		if err == nil {
+			s.save(token)
+		}
+		return token, err
+	})
+}
+
+// CanCall reports whether there is a token to call EDteam with: the one of
+// the client session or the credentials of the server.
+func (s *Session) CanCall(ctx context.Context) bool {
+	_, ok := sessionToken(ctx)
+	return ok || s.email != ""
+}
+
+// Do calls fn with the current token. When EDteam answers 401 the session
+// logs in again and fn is called one more time with the new token. The token
+// of the client session, when there is one, is used as is: the server can't
+// log in again for the client.
+func (s *Session) Do(ctx context.Context, fn func(token string) error) error {
+	if token, ok := sessionToken(ctx); ok {
+		err := fn(token)
+		if isUnauthorized(err) {
+			return fmt.Errorf("%w: %w", ErrTokenRejected, err)
+		}
+		return err
+	}
+	if s.email == "" {
+		return ErrMissingToken
+	}
+
+	token := s.Token()
+	err := fn(token)
+	if !isUnauthorized(err) {
+		return err
+	}
+
+	token, errRefresh := s.refresh(ctx, token)
+	if errRefresh != nil {
+		log.Printf("failed to re-authenticate: %v", errRefresh)
+		return err
+	}
+
+	return fn(token)
+}
+
+func isUnauthorized(err error) bool {
+	return errors.Is(err, edteam.ErrUnauthorized)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// TokenClaims are the registered claims of a JWT and its scopes.
+type TokenClaims struct {
+	Algorithm string     `json:"algorithm,omitempty"`
+	Issuer    string     `json:"issuer,omitempty"`
+	Subject   string     `json:"subject,omitempty"`
+	Audience  []string   `json:"audience,omitempty"`
+	IssuedAt  *time.Time `json:"issued_at,omitempty"`
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Scopes    []string   `json:"scopes,omitempty"`
+}
+
+// parseTokenClaims decodes the claims of token when it is a JWT. The
+// signature isn't verified, EDteam does it on every call.
+func parseTokenClaims(token string) (TokenClaims, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return TokenClaims{}, false
+	}
+	rawHeader, errHeader := base64.RawURLEncoding.DecodeString(parts[0])
+	rawPayload, errPayload := base64.RawURLEncoding.DecodeString(parts[1])
+	if errHeader != nil || errPayload != nil {
+		return TokenClaims{}, false
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	var payload struct {
+		Iss    string          `json:"iss"`
+		Sub    string          `json:"sub"`
+		Aud    json.RawMessage `json:"aud"`
+		Iat    float64         `json:"iat"`
+		Nbf    float64         `json:"nbf"`
+		Exp    float64         `json:"exp"`
+		Scope  json.RawMessage `json:"scope"`
+		Scopes json.RawMessage `json:"scopes"`
+		Scp    json.RawMessage `json:"scp"`
+	}
+	if json.Unmarshal(rawHeader, &header) != nil || json.Unmarshal(rawPayload, &payload) != nil {
+		return TokenClaims{}, false
+	}
+
+	claims := TokenClaims{
+		Algorithm: header.Alg,
+		Issuer:    payload.Iss,
+		Subject:   payload.Sub,
+		Audience:  stringOrList(payload.Aud),
+		IssuedAt:  unixClaim(payload.Iat),
+		NotBefore: unixClaim(payload.Nbf),
+		ExpiresAt: unixClaim(payload.Exp),
+	}
+	for _, scopes := range []json.RawMessage{payload.Scope, payload.Scopes, payload.Scp} {
+		if list := stringOrList(scopes); len(list) > 0 {
+			claims.Scopes = list
+			break
+		}
+	}
+
+	return claims, true
+}
+
+// stringOrList decodes a claim that is a list or a string, the scopes of
+// OAuth are separated by spaces.
+func stringOrList(raw json.RawMessage) []string {
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		return list
+	}
+
+	var value string
+	if json.Unmarshal(raw, &value) == nil {
+		return strings.Fields(value)
+	}
+
+	return nil
+}
+
+func unixClaim(seconds float64) *time.Time {
+	if seconds <= 0 {
+		return nil
+	}
+	t := time.Unix(int64(seconds), 0).UTC()
+
+	return &t
+}
+
+// SessionInfo describes the token the tools are using, never the token
+// itself.
+type SessionInfo struct {
+	// Format is jwt or opaque, the claims are only known for a JWT.
+	Format string `json:"format"`
+	// Fingerprint tells two tokens apart, it is the start of their SHA-256.
+	Fingerprint string `json:"fingerprint"`
+	TokenClaims
+	ExpiresAtHuman string `json:"expires_at_human,omitempty"`
+	Expired        bool   `json:"expired"`
+	// MultiTenant is set when the token comes from the client, in the
+	// TokenHeader header, instead of the login of the server.
+	MultiTenant bool `json:"multi_tenant"`
+}
+
+func sessionInfo(token string, multiTenant bool, now time.Time, locale Locale) SessionInfo {
+	sum := sha256.Sum256([]byte(token))
+	info := SessionInfo{
+		Format:      "opaque",
+		Fingerprint: hex.EncodeToString(sum[:])[:12],
+		MultiTenant: multiTenant,
+	}
+
+	claims, ok := parseTokenClaims(token)
+	if !ok {
+		return info
+	}
+	info.Format = "jwt"
+	info.TokenClaims = claims
+	if claims.ExpiresAt != nil {
+		info.ExpiresAtHuman = relativeTime(now, *claims.ExpiresAt, locale)
+		info.Expired = !now.Before(*claims.ExpiresAt)
+	}
+
+	return info
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	SortPriceAsc  = "price_asc"
+	SortPriceDesc = "price_desc"
+	SortNewest    = "newest"
+	SortName      = "name"
+)
+
+var sortOptions = []string{SortPriceAsc, SortPriceDesc, SortNewest, SortName}
+
+// sortCurrency is the currency of the prices compared by the price sorts
+// when they aren't converted and there are no exchange rates.
+const sortCurrency = "USD"
+
+// sortCourses sorts the courses in place, after applyCurrencies. Courses
+// without a price are left at the end when sorting by price.
+func sortCourses(courses *CoursesView, by string, rates Rates) error {
+	data := courses.Data
+
+	price := func(i int) float64 {
+		return sortPrice(&data[i], rates)
+	}
+
+	var less func(i, j int) bool
+	switch by {
+	case "":
+		return nil
+	case SortPriceAsc, SortPriceDesc:
+		less = func(i, j int) bool {
+			pi, pj := price(i), price(j)
+			if math.IsNaN(pi) || math.IsNaN(pj) {
+				return !math.IsNaN(pi) && math.IsNaN(pj)
+			}
+			if by == SortPriceDesc {
+				return pi > pj
+			}
+			return pi < pj
+		}
+	case SortNewest:
+		less = func(i, j int) bool {
+			return data[i].Course.CreatedAt.After(data[j].Course.CreatedAt.Time)
+		}
+	case SortName:
+		less = func(i, j int) bool {
+			return strings.ToLower(data[i].Course.Name) < strings.ToLower(data[j].Course.Name)
+		}
+	default:
+		return fmt.Errorf("unknown sort %q, use one of: %s", by, strings.Join(sortOptions, ", "))
+	}
+
+	// Ties are broken by ID, so a cursor points to the same page whatever
+	// order EDteam returned the catalog in.
+	sort.Slice(data, func(i, j int) bool {
+		if less(i, j) {
+			return true
+		}
+		if less(j, i) {
+			return false
+		}
+		return data[i].Course.ID < data[j].Course.ID
+	})
+
+	return nil
+}
+
+// sortPrice is the price of the course compared by the price sorts. The
+// amounts are all in one currency: the one the prices were converted into,
+// the base of the rates when they are loaded or else sortCurrency. It is NaN
+// when the course has no price in that currency.
+func sortPrice(item *CourseView, rates Rates) float64 {
+	for _, p := range item.CoursePrices {
+		switch {
+		case p.ConvertedPrice != nil:
+			return *p.ConvertedPrice
+		case rates.Base != "":
+			if converted, err := rates.Convert(float64(p.Price), p.Currency, rates.Base); err == nil {
+				return converted
+			}
+		case p.Currency == sortCurrency:
+			return float64(p.Price)
+		}
+	}
+
+	return math.NaN()
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"edteam-mcp/pkg/edteam"
+)
+
+func TestSortByPriceInTwoCurrencies(t *testing.T) {
+	codes := map[int]string{1: "USD", 2: "PEN"}
+	rates := Rates{Base: "USD", Rates: map[string]float64{"USD": 1, "PEN": 3.75}}
+	catalog := CourseResponse{Data: []Course{
+		{Course: edteam.CourseDetails{ID: 1}, CoursePrices: []CoursePrice{{CurrencyId: 2, Price: 100}}},
+		{Course: edteam.CourseDetails{ID: 2}, CoursePrices: []CoursePrice{{CurrencyId: 1, Price: 50}}},
+		{Course: edteam.CourseDetails{ID: 3}, CoursePrices: []CoursePrice{{CurrencyId: 1, Price: 40}}},
+		{Course: edteam.CourseDetails{ID: 4}},
+	}}
+	tests := []struct {
+		name   string
+		target string
+		rates  Rates
+		by     string
+		want   []int
+	}{
+		// 100 PEN are 26.67 USD.
+		{name: "converted prices", target: "USD", rates: rates, by: SortPriceAsc, want: []int{1, 3, 2, 4}},
+		{name: "base of the rates", rates: rates, by: SortPriceAsc, want: []int{1, 3, 2, 4}},
+		{name: "base of the rates descending", rates: rates, by: SortPriceDesc, want: []int{2, 3, 1, 4}},
+		{name: "without rates", by: SortPriceAsc, want: []int{3, 2, 1, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			courses := newCoursesView(catalog)
+			if err := applyCurrencies(&courses, codes, tt.rates, tt.target); err != nil {
+				t.Fatal(err)
+			}
+			if err := sortCourses(&courses, tt.by, tt.rates); err != nil {
+				t.Fatal(err)
+			}
+			var got []int
+			for _, course := range courses.Data {
+				got = append(got, course.Course.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("sortCourses(%s) = %v, want %v", tt.by, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"edteam-mcp/pkg/edteam"
+)
+
+type StudyPlan struct {
+	Goal         string       `json:"goal"`
+	WeeklyHours  int          `json:"weekly_hours"`
+	StartsAt     string       `json:"starts_at"`
+	Deadline     string       `json:"deadline,omitempty"`
+	FitsDeadline *bool        `json:"fits_deadline,omitempty"`
+	TotalHours   float64      `json:"total_hours"`
+	WeeksNeeded  int          `json:"weeks_needed"`
+	Courses      []PlanCourse `json:"courses"`
+	Weeks        []StudyWeek  `json:"weeks"`
+	Message      string       `json:"message"`
+}
+
+type PlanCourse struct {
+	CourseID int     `json:"course_id"`
+	Name     string  `json:"name"`
+	Slug     string  `json:"slug"`
+	Classes  int     `json:"classes"`
+	Hours    float64 `json:"hours"`
+}
+
+type StudyWeek struct {
+	Week     int         `json:"week"`
+	StartsAt string      `json:"starts_at"`
+	EndsAt   string      `json:"ends_at"`
+	Hours    float64     `json:"hours"`
+	Sessions []StudyItem `json:"sessions"`
+}
+
+type StudyItem struct {
+	CourseID   int      `json:"course_id"`
+	CourseName string   `json:"course_name"`
+	Classes    []string `json:"classes"`
+	Minutes    int      `json:"minutes"`
+}
+
+type planClass struct {
+	courseIndex int
+	name        string
+	seconds     int
+}
+
+// recommendCourses returns the indexes of the courses that best match the
+// words of the goal.
+func recommendCourses(courses CourseResponse, goal string, max int) []int {
+	var words []string
+	for _, word := range strings.Fields(strings.ToLower(goal)) {
+		word = strings.Trim(word, `.,;:¿?¡!()"'`)
+		if len([]rune(word)) >= 3 {
+			words = append(words, word)
+		}
+	}
+
+	type scored struct {
+		index int
+		score int
+	}
+	var matches []scored
+	for i, item := range courses.Data {
+		name := strings.ToLower(item.Course.Name)
+		text := strings.ToLower(strings.Join([]string{item.Course.Subtitle, item.Course.YouLearn, item.Course.AddressedTo}, " "))
+		score := 0
+		for _, word := range words {
+			// A match in the name weighs more than one in the description.
+			score += 3*strings.Count(name, word) + strings.Count(text, word)
+		}
+		if score > 0 {
+			matches = append(matches, scored{index: i, score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	indexes := make([]int, 0, max)
+	for _, match := range matches {
+		if len(indexes) == max {
+			break
+		}
+		indexes = append(indexes, match.index)
+	}
+
+	return indexes
+}
+
+// buildStudyPlan distributes the classes of the courses, in order, among weeks
+// of weeklyHours starting at start.
+func buildStudyPlan(ctx context.Context, api edteam.CoursesAPI, courses CourseResponse, indexes []int, goal string, weeklyHours int, start time.Time, deadline *time.Time, locale Locale) (StudyPlan, error) {
+	plan := StudyPlan{
+		Goal:        goal,
+		WeeklyHours: weeklyHours,
+		StartsAt:    start.Format(time.DateOnly),
+		Courses:     []PlanCourse{},
+		Weeks:       []StudyWeek{},
+	}
+	if deadline != nil {
+		plan.Deadline = deadline.Format(time.DateOnly)
+	}
+
+	var classes []planClass
+	var totalSeconds int
+	for _, i := range indexes {
+		item := courses.Data[i]
+		curriculum, err := api.Curriculum(ctx, item.Course.Slug)
+		if err != nil {
+			return StudyPlan{}, err
+		}
+
+		count, seconds := curriculum.Duration()
+		plan.Courses = append(plan.Courses, PlanCourse{
+			CourseID: item.Course.ID,
+			Name:     item.Course.Name,
+			Slug:     item.Course.Slug,
+			Classes:  count,
+			Hours:    hours(seconds),
+		})
+		totalSeconds += seconds
+
+		for _, section := range curriculum.Data {
+			for _, class := range section.Classes {
+				classes = append(classes, planClass{courseIndex: len(plan.Courses) - 1, name: class.Name, seconds: class.Duration})
+			}
+		}
+	}
+	plan.TotalHours = hours(totalSeconds)
+
+	capacity := weeklyHours * 3600
+	var week *StudyWeek
+	used := 0
+	for _, class := range classes {
+		// A class longer than a whole week still takes a week of its own.
+		if week == nil || (used > 0 && used+class.seconds > capacity) {
+			number := len(plan.Weeks) + 1
+			startsAt := start.AddDate(0, 0, 7*(number-1))
+			plan.Weeks = append(plan.Weeks, StudyWeek{
+				Week:     number,
+				StartsAt: startsAt.Format(time.DateOnly),
+				EndsAt:   startsAt.AddDate(0, 0, 6).Format(time.DateOnly),
+			})
+			week = &plan.Weeks[len(plan.Weeks)-1]
+			used = 0
+		}
+
+		course := plan.Courses[class.courseIndex]
+		n := len(week.Sessions)
+		if n == 0 || week.Sessions[n-1].CourseID != course.CourseID {
+			week.Sessions = append(week.Sessions, StudyItem{CourseID: course.CourseID, CourseName: course.Name})
+			n++
+		}
+		session := &week.Sessions[n-1]
+		session.Classes = append(session.Classes, class.name)
+		session.Minutes += int(math.Ceil(float64(class.seconds) / 60))
+		used += class.seconds
+		week.Hours = hours(used)
+	}
+	plan.WeeksNeeded = len(plan.Weeks)
+
+	switch {
+	case len(plan.Weeks) == 0:
+		plan.Message = locale.T("study_plan_empty")
+	case deadline != nil:
+		lastDay := start.AddDate(0, 0, 7*plan.WeeksNeeded-1)
+		fits := !lastDay.After(*deadline)
+		plan.FitsDeadline = &fits
+		if fits {
+			plan.Message = locale.T("study_plan_fits", plan.WeeksNeeded)
+		} else {
+			needed := int(math.Ceil(float64(totalSeconds) / 3600 / math.Max(1, math.Floor(deadline.Sub(start).Hours()/24/7))))
+			plan.Message = locale.T("study_plan_late", plan.WeeksNeeded, needed)
+		}
+	default:
+		plan.Message = locale.T("study_plan_weeks", plan.WeeksNeeded)
+	}
+
+	return plan, nil
+}
+
+func hours(seconds int) float64 {
+	return math.Round(float64(seconds)/3600*10) / 10
+}
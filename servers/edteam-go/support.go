@@ -0,0 +1,5 @@
+package main
+
+import "edteam-mcp/pkg/edteam"
+
+var ticketCategories = []string{edteam.TicketBilling, edteam.TicketAccess, edteam.TicketTechnical, edteam.TicketCertificate, edteam.TicketOther}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"edteam-mcp/pkg/edteam"
+)
+
+// ErrEgressBlocked is returned for the requests to hosts other than EDteam
+// when TELEMETRY is off.
+var ErrEgressBlocked = errors.New("TELEMETRY is off, only EDteam can be called")
+
+// edteamOnlyTransport refuses every request to a host other than EDteam
+// before it leaves the process, so nothing else is sent even when a URL
+// comes from the configuration.
+type edteamOnlyTransport struct {
+	next http.RoundTripper
+}
+
+func newEDteamOnlyTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &edteamOnlyTransport{next: next}
+}
+
+func (t *edteamOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !edteam.IsHost(req.URL.Hostname()) {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf("%w, refused %s", ErrEgressBlocked, req.URL.Hostname())
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// checkEgress fails when a URL of the configuration points outside EDteam
+// while TELEMETRY is off.
+func checkEgress(name, source string) error {
+	u, err := url.Parse(source)
+	if err != nil || edteam.IsHost(u.Hostname()) {
+		return nil
+	}
+
+	return fmt.Errorf("%s %q is not an EDteam URL and TELEMETRY is off, use a file instead", name, source)
+}
+
+// envSwitch reads a variable set to on or off, or any boolean.
+func envSwitch(name string, fallback bool) (bool, error) {
+	switch value := strings.ToLower(envString(name, "")); value {
+	case "":
+		return fallback, nil
+	case "on":
+		return true, nil
+	case "off":
+		return false, nil
+	default:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, fmt.Errorf("%s must be on or off, got %q", name, value)
+		}
+		return b, nil
+	}
+}
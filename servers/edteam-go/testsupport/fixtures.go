@@ -0,0 +1,21 @@
+package testsupport
+
+import (
+	"embed"
+	"testing"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Fixture returns the canned JSON stored in fixtures/<name>.json.
+func Fixture(t testing.TB, name string) []byte {
+	t.Helper()
+
+	data, err := fixtures.ReadFile("fixtures/" + name + ".json")
+	if err != nil {
+		t.Fatalf("fixture %q not found: %v", name, err)
+	}
+
+	return data
+}
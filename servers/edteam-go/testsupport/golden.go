@@ -0,0 +1,47 @@
+package testsupport
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// AssertGolden compares got with testdata/<name>.golden. Run the tests with
+// UPDATE_GOLDEN=1 to rewrite the golden file with the current output.
+func AssertGolden(t testing.TB, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if os.Getenv("UPDATE_GOLDEN") == "1" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with UPDATE_GOLDEN=1 to create it): %v", err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("%s does not match golden file\n--- want\n%s\n--- got\n%s", name, want, got)
+	}
+}
+
+// AssertGoldenJSON marshals v as indented JSON and compares it with
+// testdata/<name>.golden.
+func AssertGoldenJSON(t testing.TB, name string, v any) {
+	t.Helper()
+
+	got, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal %s: %v", name, err)
+	}
+
+	AssertGolden(t, name, append(got, '\n'))
+}
@@ -0,0 +1,116 @@
+// Package testsupport provides helpers shared by the tests of the servers: a
+// fake EDteam API, canned JSON fixtures and golden-file assertions.
+package testsupport
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// Token is the token returned by the fake login endpoint and required by the
+// private endpoints.
+const Token = "fake-token"
+
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+// FakeEDteam is an httptest server that answers the EDteam endpoints used by
+// the servers with the canned fixtures. Every route can be overridden.
+type FakeEDteam struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	routes   map[string]http.HandlerFunc
+	requests []RecordedRequest
+}
+
+// NewFakeEDteam starts a fake EDteam API which is closed when the test ends.
+func NewFakeEDteam(t testing.TB) *FakeEDteam {
+	t.Helper()
+
+	f := &FakeEDteam{routes: make(map[string]http.HandlerFunc)}
+	f.RespondWith(http.MethodPost, "/api/v1/login", http.StatusOK, Fixture(t, "login"))
+	f.Handle(http.MethodGet, "/api/v1/subscriptions/historical", f.private(http.StatusOK, Fixture(t, "subscriptions")))
+	f.RespondWith(http.MethodPost, "/v2/public/cache-edql", http.StatusOK, Fixture(t, "courses"))
+	f.Handle(http.MethodPost, "/v2/private/shopping-carts", f.private(http.StatusCreated, Fixture(t, "shopping_cart")))
+
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+// Handle overrides the handler of the given route.
+func (f *FakeEDteam) Handle(method, path string, handler http.HandlerFunc) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.routes[method+" "+path] = handler
+}
+
+// RespondWith makes the given route answer with a fixed status and JSON body.
+func (f *FakeEDteam) RespondWith(method, path string, status int, body []byte) {
+	f.Handle(method, path, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, status, body)
+	})
+}
+
+// Requests returns the requests received so far.
+func (f *FakeEDteam) Requests() []RecordedRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]RecordedRequest(nil), f.requests...)
+}
+
+func (f *FakeEDteam) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	f.requests = append(f.requests, RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	handler, ok := f.routes[r.Method+" "+r.URL.Path]
+	f.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, http.StatusNotFound, []byte(fmt.Sprintf(`{"messages":[{"title":"Not found","message":"no route for %s %s","code":"404"}]}`, r.Method, r.URL.Path)))
+		return
+	}
+
+	handler(w, r)
+}
+
+func (f *FakeEDteam) private(status int, body []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+Token {
+			writeJSON(w, http.StatusUnauthorized, []byte(`{"messages":[{"title":"Unauthorized","message":"invalid token","code":"401"}]}`))
+			return
+		}
+		writeJSON(w, status, body)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(body); err != nil {
+		log.Printf("failed to write fake response: %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+package testsupport
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"edteam-mcp/vcr"
+)
+
+// Replay returns a transport answering from testdata/cassettes/<name>.json,
+// recorded with VCR_MODE=record against the real EDteam API.
+func Replay(t testing.TB, name string) http.RoundTripper {
+	t.Helper()
+
+	transport, err := vcr.New(vcr.ModeReplay, filepath.Join("testdata", "cassettes", name+".json"), nil)
+	if err != nil {
+		t.Fatalf("failed to load cassette %q: %v", name, err)
+	}
+
+	return transport
+}
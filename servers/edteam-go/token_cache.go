@@ -0,0 +1,213 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+const (
+	KDFPassphrase = "pbkdf2-sha256"
+	KDFKeyring    = "keyring"
+
+	// pbkdf2Iterations follows the OWASP recommendation for PBKDF2-SHA256.
+	pbkdf2Iterations = 600_000
+
+	keyringService = "edteam-mcp"
+	keyringAccount = "token-cache"
+)
+
+// tokenCacheAAD binds the ciphertext to its use, a blob sealed for something
+// else doesn't open as a token.
+var tokenCacheAAD = []byte("edteam-mcp token cache v1")
+
+// sealedFile is the format of the token cache: the JSON of the token
+// encrypted with AES-256-GCM.
+type sealedFile struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt,omitempty"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// TokenCipher encrypts the token cache, the data directory may be synced or
+// backed up. The key is derived from TOKEN_PASSPHRASE or, without one, a
+// random key kept in the OS keyring.
+type TokenCipher struct {
+	passphrase string
+	key        []byte
+}
+
+// NewTokenCipher fails when there is no passphrase and no keyring to keep
+// the key in.
+func NewTokenCipher(passphrase string) (*TokenCipher, error) {
+	if passphrase != "" {
+		return &TokenCipher{passphrase: passphrase}, nil
+	}
+
+	key, err := keyringKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenCipher{key: key}, nil
+}
+
+func (c *TokenCipher) Seal(plaintext []byte) ([]byte, error) {
+	file := sealedFile{Version: 1, KDF: KDFKeyring}
+	key := c.key
+	if c.passphrase != "" {
+		file.KDF = KDFPassphrase
+		file.Salt = make([]byte, 16)
+		rand.Read(file.Salt)
+		var err error
+		key, err = pbkdf2.Key(sha256.New, c.passphrase, file.Salt, pbkdf2Iterations, 32)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	file.Nonce = make([]byte, aead.NonceSize())
+	rand.Read(file.Nonce)
+	file.Data = aead.Seal(nil, file.Nonce, plaintext, tokenCacheAAD)
+
+	return json.Marshal(file)
+}
+
+func (c *TokenCipher) Open(data []byte) ([]byte, error) {
+	var file sealedFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Version != 1 {
+		return nil, errors.New("the token cache is not encrypted or has an unknown format")
+	}
+
+	key := c.key
+	switch {
+	case file.KDF == KDFPassphrase && c.passphrase != "":
+		var err error
+		key, err = pbkdf2.Key(sha256.New, c.passphrase, file.Salt, pbkdf2Iterations, 32)
+		if err != nil {
+			return nil, err
+		}
+	case file.KDF == KDFKeyring && c.key != nil:
+	default:
+		return nil, fmt.Errorf("the token cache was encrypted with the %s key", file.KDF)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != aead.NonceSize() {
+		return nil, errors.New("the token cache is corrupted")
+	}
+	plaintext, err := aead.Open(nil, file.Nonce, file.Data, tokenCacheAAD)
+	if err != nil {
+		return nil, errors.New("the token cache can't be decrypted, the key changed or the file is corrupted")
+	}
+
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// errKeyringNotFound is returned by keyringGet when the keyring has no key
+// for the token cache yet.
+var errKeyringNotFound = errors.New("the token key is not in the OS keyring")
+
+// keyringKey returns the key of the token cache from the OS keyring, creating
+// it on first use. It uses the security command on macOS and secret-tool, of
+// libsecret, on Linux. Only a missing key is created, a keyring that is
+// locked or fails keeps the key it has.
+func keyringKey() ([]byte, error) {
+	encoded, err := keyringGet()
+	switch {
+	case err == nil:
+		key, errDecode := base64.StdEncoding.DecodeString(encoded)
+		if errDecode != nil || len(key) != 32 {
+			return nil, fmt.Errorf("the token key in the OS keyring is not a base64 encoded 32 bytes key, remove the %s entry to create a new one", keyringService)
+		}
+		return key, nil
+	case errors.Is(err, exec.ErrNotFound) || errors.Is(err, errors.ErrUnsupported):
+		return nil, fmt.Errorf("there is no OS keyring to keep the token key: %w", err)
+	case !errors.Is(err, errKeyringNotFound):
+		return nil, fmt.Errorf("failed to read the token key from the OS keyring: %w", err)
+	}
+
+	key := make([]byte, 32)
+	rand.Read(key)
+	if err := keyringSet(base64.StdEncoding.EncodeToString(key)); err != nil {
+		return nil, fmt.Errorf("failed to store the token key in the OS keyring: %w", err)
+	}
+
+	return key, nil
+}
+
+// keyringGet returns errKeyringNotFound for a missing key: security exits
+// with 44 and secret-tool exits with 1 without printing an error.
+func keyringGet() (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", keyringService, "-a", keyringAccount, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", keyringService, "account", keyringAccount)
+	default:
+		return "", fmt.Errorf("%w on %s", errors.ErrUnsupported, runtime.GOOS)
+	}
+
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		stderr := strings.TrimSpace(string(exitErr.Stderr))
+		switch {
+		case runtime.GOOS == "darwin" && exitErr.ExitCode() == 44,
+			runtime.GOOS == "linux" && exitErr.ExitCode() == 1 && stderr == "":
+			return "", errKeyringNotFound
+		case stderr != "":
+			return "", fmt.Errorf("%w: %s", err, stderr)
+		}
+	}
+	if err == nil && strings.TrimSpace(string(out)) == "" {
+		return "", errKeyringNotFound
+	}
+
+	return strings.TrimSpace(string(out)), err
+}
+
+func keyringSet(value string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		// security only takes the secret as an argument, it is visible to
+		// the processes of the same user for a moment.
+		cmd = exec.Command("security", "add-generic-password", "-U", "-s", keyringService, "-a", keyringAccount, "-w", value)
+	case "linux":
+		cmd = exec.Command("secret-tool", "store", "--label=EDteam MCP token cache", "service", keyringService, "account", keyringAccount)
+		cmd.Stdin = strings.NewReader(value)
+	default:
+		return fmt.Errorf("%w on %s", errors.ErrUnsupported, runtime.GOOS)
+	}
+
+	return cmd.Run()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// TokenStore keeps the EDteam token of every account, it is safe for
+// concurrent use. Reading a token never waits for a login in progress.
+type TokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]string
+
+	// refreshing serializes the logins, so the calls that find the same
+	// expired token log in once.
+	refreshing sync.Mutex
+}
+
+func NewTokenStore() *TokenStore {
+	return &TokenStore{tokens: make(map[string]string)}
+}
+
+// Get returns the token of account, empty when there is none.
+func (s *TokenStore) Get(account string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.tokens[account]
+}
+
+func (s *TokenStore) Set(account, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[account] = token
+}
+
+// Refresh replaces the expired token of account with the one returned by
+// login. When another call already replaced it, the new token is returned
+// without logging in again.
+func (s *TokenStore) Refresh(ctx context.Context, account, expired string, login func(ctx context.Context) (string, error)) (string, error) {
+	s.refreshing.Lock()
+	defer s.refreshing.Unlock()
+
+	if token := s.Get(account); token != expired {
+		return token, nil
+	}
+
+	token, err := login(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.Set(account, token)
+
+	return token, nil
+}
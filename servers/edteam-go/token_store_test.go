@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"edteam-mcp/pkg/edteam"
+	"edteam-mcp/testsupport"
+)
+
+// logins counts the requests to the login route of fake.
+func logins(fake *testsupport.FakeEDteam) int {
+	var n int
+	for _, request := range fake.Requests() {
+		if request.Method == http.MethodPost && request.Path == "/api/v1/login" {
+			n++
+		}
+	}
+
+	return n
+}
+
+func newFakeSession(t *testing.T, fake *testsupport.FakeEDteam) *Session {
+	t.Helper()
+
+	client, err := edteam.New(edteam.WithBaseURL(fake.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := NewSession(context.Background(), client, "student@example.com", "secret")
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+
+	return session
+}
+
+// TestSessionConcurrentRefresh expires the token under calls made at the
+// same time: they all get the 401, a single one logs in again and every
+// call is retried with the new token.
+func TestSessionConcurrentRefresh(t *testing.T) {
+	fake := testsupport.NewFakeEDteam(t)
+	session := newFakeSession(t, fake)
+	session.tokens.Set(session.email, "expired-token")
+
+	const calls = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, calls)
+	for range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- session.Do(context.Background(), func(token string) error {
+				_, err := session.client.Subscriptions.List(context.Background(), token)
+				return err
+			})
+		}()
+	}
+	// The token is read while the calls refresh it.
+	for range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			session.CanCall(context.Background())
+			session.Token()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Do() error = %v", err)
+		}
+	}
+	if got := session.Token(); got != testsupport.Token {
+		t.Errorf("Token() = %q, want %q", got, testsupport.Token)
+	}
+	// NewSession logged in once, the refresh once more.
+	if got := logins(fake); got != 2 {
+		t.Errorf("the session logged in %d times, want 2", got)
+	}
+}
+
+func TestSessionRefreshFails(t *testing.T) {
+	fake := testsupport.NewFakeEDteam(t)
+	session := newFakeSession(t, fake)
+	session.tokens.Set(session.email, "expired-token")
+	fake.RespondWith(http.MethodPost, "/api/v1/login", http.StatusUnauthorized, []byte(`{"messages":[{"title":"Error","message":"invalid credentials"}]}`))
+
+	err := session.Do(context.Background(), func(token string) error {
+		_, err := session.client.Subscriptions.List(context.Background(), token)
+		return err
+	})
+	// The 401 of the call is returned, not the one of the login.
+	if !errors.Is(err, edteam.ErrUnauthorized) {
+		t.Errorf("Do() error = %v, want ErrUnauthorized", err)
+	}
+	if got := session.Token(); got != "expired-token" {
+		t.Errorf("Token() = %q, want the expired token kept", got)
+	}
+}
+
+func TestTokenStoreAccounts(t *testing.T) {
+	store := NewTokenStore()
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		account := fmt.Sprintf("account-%d@example.com", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store.Set(account, "expired")
+			token, err := store.Refresh(context.Background(), account, "expired", func(ctx context.Context) (string, error) {
+				return "token of " + account, nil
+			})
+			if err != nil || token != "token of "+account {
+				t.Errorf("Refresh(%s) = %q, %v", account, token, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range 10 {
+		account := fmt.Sprintf("account-%d@example.com", i)
+		if got := store.Get(account); got != "token of "+account {
+			t.Errorf("Get(%s) = %q, want its own token", account, got)
+		}
+	}
+	if got := store.Get("nobody@example.com"); got != "" {
+		t.Errorf("Get() of an unknown account = %q, want empty", got)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+
+	"edteam-mcp/pkg/edteam"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	CategoryAuth           = "auth"
+	CategoryNotFound       = "not_found"
+	CategoryRateLimited    = "rate_limited"
+	CategoryUpstreamDown   = "upstream_down"
+	CategoryInvalidRequest = "invalid_request"
+	CategoryCancelled      = "cancelled"
+	CategoryUnknown        = "unknown"
+)
+
+const (
+	CodeSessionExpired  = "session_expired"
+	CodeForbidden       = "forbidden"
+	CodeRateLimited     = "rate_limited"
+	CodeAmbiguous       = "ambiguous"
+	CodeTimeout         = "timeout"
+	CodeMissingToken    = "missing_token"
+	CodeTokenRejected   = "token_rejected"
+	CodeToolRateLimited = "tool_rate_limited"
+	CodeBusy            = "busy"
+	CodeTooManyJobs     = "too_many_jobs"
+	CodeTooLarge        = "response_too_large"
+	CodeSpendingCap     = "spending_cap"
+	CodeUnavailable     = "endpoint_unavailable"
+)
+
+// ToolError is the payload of the error results, it tells the model what
+// went wrong and what it can do about it. Code refines the category for the
+// statuses that need a different reaction.
+type ToolError struct {
+	Category        string `json:"category"`
+	Code            string `json:"code"`
+	Status          int    `json:"status,omitempty"`
+	Message         string `json:"message"`
+	UpstreamMessage string `json:"upstream_message,omitempty"`
+	NextStep        string `json:"next_step"`
+	Retryable       bool   `json:"retryable"`
+	// RetryAfter is set when the server limits how often the tool is called.
+	RetryAfter int `json:"retry_after_seconds,omitempty"`
+}
+
+func classifyError(err error, locale Locale) ToolError {
+	toolError := ToolError{
+		Category: CategoryUnknown,
+		Message:  err.Error(),
+	}
+
+	var statusErr *StatusError
+	var nonJSONErr *NonJSONError
+	var netErr net.Error
+	var argErr *ArgumentError
+	var ambiguousErr *AmbiguousError
+	var rateLimitErr *RateLimitError
+	var tooLargeErr *ResponseTooLargeError
+	var spendingCapErr *SpendingCapError
+	var unavailableErr *UnavailableError
+	switch {
+	case errors.Is(err, ErrBusy):
+		toolError.Category = CategoryRateLimited
+		toolError.Code = CodeBusy
+	case errors.As(err, &rateLimitErr):
+		toolError.Category = CategoryRateLimited
+		toolError.Code = CodeToolRateLimited
+		toolError.RetryAfter = retryAfterSeconds(rateLimitErr.RetryAfter)
+	case errors.As(err, &spendingCapErr):
+		toolError.Category = CategoryInvalidRequest
+		toolError.Code = CodeSpendingCap
+	case errors.Is(err, ErrTooManyJobs):
+		toolError.Category = CategoryRateLimited
+		toolError.Code = CodeTooManyJobs
+	case errors.Is(err, ErrJobNotFound):
+		toolError.Category = CategoryNotFound
+	case errors.As(err, &ambiguousErr):
+		toolError.Category = CategoryUpstreamDown
+		toolError.Code = CodeAmbiguous
+	case errors.Is(err, ErrMissingToken):
+		toolError.Category = CategoryAuth
+		toolError.Code = CodeMissingToken
+	case errors.Is(err, ErrTokenRejected):
+		toolError.Category = CategoryAuth
+		toolError.Code = CodeTokenRejected
+		toolError.Status = http.StatusUnauthorized
+	case errors.Is(err, context.Canceled):
+		toolError.Category = CategoryCancelled
+	case errors.Is(err, context.DeadlineExceeded):
+		toolError.Category = CategoryUpstreamDown
+		toolError.Code = CodeTimeout
+	case errors.As(err, &argErr):
+		toolError.Category = CategoryInvalidRequest
+	case errors.As(err, &tooLargeErr):
+		toolError.Category = CategoryUpstreamDown
+		toolError.Code = CodeTooLarge
+		toolError.Status = tooLargeErr.StatusCode
+	case errors.As(err, &unavailableErr):
+		// Checked before the *StatusError it wraps, a 404 of these endpoints
+		// doesn't mean the resource is missing.
+		toolError.Category = CategoryNotFound
+		toolError.Code = CodeUnavailable
+		toolError.Status = unavailableErr.Err.Code
+	case errors.As(err, &statusErr):
+		toolError.Status = statusErr.Code
+		toolError.UpstreamMessage = statusErr.Message()
+		toolError.Category = statusCategory(statusErr.Code)
+		switch {
+		case errors.Is(statusErr, edteam.ErrUnauthorized):
+			toolError.Code = CodeSessionExpired
+		case statusErr.Code == http.StatusForbidden:
+			toolError.Code = CodeForbidden
+		case errors.Is(statusErr, edteam.ErrRateLimited):
+			toolError.Code = CodeRateLimited
+		}
+	case errors.As(err, &nonJSONErr):
+		toolError.Status = nonJSONErr.StatusCode
+		toolError.UpstreamMessage = nonJSONErr.Snippet
+		toolError.Category = statusCategory(nonJSONErr.StatusCode)
+		if toolError.Category == CategoryUnknown {
+			// A successful status with an HTML page is a proxy or maintenance page.
+			toolError.Category = CategoryUpstreamDown
+		}
+	case errors.As(err, &netErr):
+		toolError.Category = CategoryUpstreamDown
+	}
+
+	toolError.Retryable = isTransient(err)
+	if toolError.Code == "" {
+		toolError.Code = toolError.Category
+		toolError.NextStep = locale.T("next_step_" + toolError.Category)
+	} else {
+		toolError.Message = locale.T("error_" + toolError.Code)
+		toolError.NextStep = locale.T("next_step_" + toolError.Code)
+	}
+
+	return toolError
+}
+
+func statusCategory(code int) string {
+	switch {
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return CategoryAuth
+	case code == http.StatusNotFound:
+		return CategoryNotFound
+	case code == http.StatusTooManyRequests:
+		return CategoryRateLimited
+	case code >= http.StatusInternalServerError:
+		return CategoryUpstreamDown
+	case code >= http.StatusBadRequest:
+		return CategoryInvalidRequest
+	}
+
+	return CategoryUnknown
+}
+
+// toolErrorResult converts an upstream failure into an error result the model
+// can reason about instead of a protocol error.
+func toolErrorResult(err error, locale Locale) *mcp.CallToolResult {
+	raw, errMarshal := json.Marshal(classifyError(err, locale))
+	if errMarshal != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	return mcp.NewToolResultError(string(raw))
+}
+
+// isTransient reports whether calling the tool again later may succeed.
+func isTransient(err error) bool {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) || errors.Is(err, ErrBusy) || errors.Is(err, ErrTooManyJobs) {
+		return true
+	}
+
+	return edteam.Temporary(err)
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"edteam-mcp/pkg/edteam"
+)
+
+func TestClassifyErrorUnavailable(t *testing.T) {
+	err := &UnavailableError{
+		Method: http.MethodGet,
+		URL:    "https://api.ed.team/api/v1/users/me/referrals",
+		Err:    edteam.NewStatusError(http.StatusNotFound, nil),
+	}
+
+	got := classifyError(err, LocaleEN)
+	if got.Category != CategoryNotFound || got.Code != CodeUnavailable || got.Status != http.StatusNotFound {
+		t.Errorf("classifyError() = %+v, want category %s, code %s and status 404", got, CategoryNotFound, CodeUnavailable)
+	}
+	if got.Retryable {
+		t.Error("classifyError() is retryable, want an unavailable endpoint not retried")
+	}
+	if got.Message != LocaleEN.T("error_endpoint_unavailable") {
+		t.Errorf("classifyError() message = %q, want the localized message", got.Message)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// toolSet collects the tools before they are added to the server, so the
+// configuration can choose which ones are exposed.
+type toolSet []server.ServerTool
+
+func (t *toolSet) AddTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	*t = append(*t, server.ServerTool{Tool: tool, Handler: handler})
+}
+
+// singleTenantTools aren't exposed by a multi-tenant server. The deletion is
+// confirmed with the account email, which it doesn't know. The watchlist and
+// the snapshot of Whats-New are kept by the server, one for every tenant
+// would be shared by all of them.
+var singleTenantTools = []string{"Account-Delete-Request", "Watch-Course", "Whats-New"}
+
+// exposedTools applies the tool selection, the read-only mode and the
+// language of the descriptions of cfg.
+func exposedTools(tools []server.ServerTool, cfg Config) ([]server.ServerTool, error) {
+	tools, err := selectTools(tools, cfg.EnabledTools, cfg.DisabledTools)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ReadOnly {
+		tools = removeSideEffectTools(tools)
+	}
+	tools, err = localizeTools(tools, cfg.ToolLocale)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MultiTenant {
+		tools = slices.DeleteFunc(tools, func(tool server.ServerTool) bool {
+			return slices.Contains(singleTenantTools, tool.Tool.Name)
+		})
+	}
+
+	return tools, nil
+}
+
+// selectTools removes the tools hidden by the configuration. Both lists hold
+// tool names or patterns like Shopping-*; with enabled set only the matching
+// tools are kept, and the disabled ones are removed after that. A name that
+// matches no tool is an error, it is usually a typo.
+func selectTools(tools []server.ServerTool, enabled, disabled []string) ([]server.ServerTool, error) {
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.Tool.Name)
+	}
+
+	for _, patterns := range [][]string{enabled, disabled} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid tool pattern %q: %w", pattern, err)
+			}
+			if !matchesAny(names, pattern) {
+				return nil, fmt.Errorf("no tool matches %q", pattern)
+			}
+		}
+	}
+
+	var selected []server.ServerTool
+	for _, tool := range tools {
+		if len(enabled) > 0 && !matchesTool(enabled, tool.Tool.Name) {
+			continue
+		}
+		if matchesTool(disabled, tool.Tool.Name) {
+			continue
+		}
+		selected = append(selected, tool)
+	}
+
+	return selected, nil
+}
+
+func matchesTool(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name)); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesAny(names []string, pattern string) bool {
+	for _, name := range names {
+		if matchesTool([]string{pattern}, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// removeSideEffectTools keeps only the tools annotated as read-only, so the
+// server can't buy, post or change anything. Tools without the annotation
+// are removed too, the MCP default is to assume side effects.
+func removeSideEffectTools(tools []server.ServerTool) []server.ServerTool {
+	var readOnly []server.ServerTool
+	for _, tool := range tools {
+		if hint := tool.Tool.Annotations.ReadOnlyHint; hint != nil && *hint {
+			readOnly = append(readOnly, tool)
+		}
+	}
+
+	return readOnly
+}
+
+// replaceTools exposes tools instead of the current ones. The new tools are
+// added before the old ones are removed, so calls in flight never find the
+// server without tools. The server sends tools/list_changed to the clients.
+func replaceTools(s *server.MCPServer, tools []server.ServerTool) {
+	keep := make(map[string]bool, len(tools))
+	for _, tool := range tools {
+		keep[tool.Tool.Name] = true
+	}
+	var removed []string
+	for name := range s.ListTools() {
+		if !keep[name] {
+			removed = append(removed, name)
+		}
+	}
+
+	s.AddTools(tools...)
+	if len(removed) > 0 {
+		s.DeleteTools(removed...)
+	}
+}
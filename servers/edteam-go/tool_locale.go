@@ -0,0 +1,82 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// The tools are described in English in the code, locales/tools.<locale>.json
+// translates them for TOOL_LOCALE. The models pick the tools in the language
+// of the conversation more reliably when the descriptions match it.
+//
+//go:embed locales/*.json
+var toolLocales embed.FS
+
+// ToolText is the translation of a tool. The entry "*" holds the argument
+// descriptions shared by every tool, the ones of a tool take precedence.
+type ToolText struct {
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Properties  map[string]string `json:"properties"`
+}
+
+func loadToolTexts(locale Locale) (map[string]ToolText, error) {
+	raw, err := toolLocales.ReadFile("locales/tools." + string(locale) + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("there are no tool descriptions for %q: %w", locale, err)
+	}
+
+	var texts map[string]ToolText
+	if err := json.Unmarshal(raw, &texts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the tool descriptions for %q: %w", locale, err)
+	}
+
+	return texts, nil
+}
+
+// localizeTools translates the title, the description and the argument
+// descriptions of the tools. English returns them as they are, and any text
+// missing in the locale file stays in English.
+func localizeTools(tools []server.ServerTool, locale Locale) ([]server.ServerTool, error) {
+	if locale == LocaleEN {
+		return tools, nil
+	}
+	texts, err := loadToolTexts(locale)
+	if err != nil {
+		return nil, err
+	}
+	shared := texts["*"].Properties
+
+	localized := make([]server.ServerTool, len(tools))
+	for i, tool := range tools {
+		text := texts[tool.Tool.Name]
+		if text.Title != "" {
+			tool.Tool.Annotations.Title = text.Title
+		}
+		if text.Description != "" {
+			tool.Tool.Description = text.Description
+		}
+
+		properties := make(map[string]any, len(tool.Tool.InputSchema.Properties))
+		for name, schema := range tool.Tool.InputSchema.Properties {
+			description, ok := text.Properties[name]
+			if !ok {
+				description, ok = shared[name]
+			}
+			if object, isObject := schema.(map[string]any); ok && isObject {
+				object = maps.Clone(object)
+				object["description"] = description
+				schema = object
+			}
+			properties[name] = schema
+		}
+		tool.Tool.InputSchema.Properties = properties
+		localized[i] = tool
+	}
+
+	return localized, nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"edteam-mcp/testsupport"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TestToolSchemas snapshots the tools as tools/list serializes them, a
+// change of a description or a schema changes what the models send. Run
+// with UPDATE_GOLDEN=1 to accept the changes.
+func TestToolSchemas(t *testing.T) {
+	fake := testsupport.NewFakeEDteam(t)
+	serveInProcess(t, fake, func(ctx context.Context, c *client.Client) {
+		tools, err := c.ListTools(ctx, mcp.ListToolsRequest{})
+		if err != nil {
+			t.Fatalf("ListTools() error = %v", err)
+		}
+
+		names := make([]string, 0, len(tools.Tools))
+		for _, tool := range tools.Tools {
+			names = append(names, tool.Name)
+			t.Run(tool.Name, func(t *testing.T) {
+				testsupport.AssertGoldenJSON(t, "tools/"+tool.Name, tool)
+			})
+		}
+		// A removed tool leaves its snapshot behind, the list catches it.
+		testsupport.AssertGoldenJSON(t, "tools/list", names)
+	})
+}
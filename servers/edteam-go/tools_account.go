@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// accountTools are the tools of the EDteam account: its subscriptions, its
+// progress and who the session acts as.
+func (srv *Server) accountTools(tools *toolSet, cfg Config) {
+	subscriptionsTool := mcp.NewTool(
+		"Subscriptions",
+		mcp.WithDescription("List all your subscriptions in the history of EDteam"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		fieldsOption(subscriptionFields),
+		mcp.WithString("format", mcp.Description("Output format, markdown returns a compact table and jsonl one subscription per line"), mcp.Enum(formats...), mcp.DefaultString(FormatJSON)),
+		localeOption(),
+	)
+
+	tools.AddTool(subscriptionsTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(req.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		format := args.String("format", FormatJSON, formats...)
+		fields := args.Strings("fields", subscriptionFields)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var subscriptions SubscriptionResponse
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			subscriptions, err = srv.client.Subscriptions.List(ctx, token)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		view := newSubscriptionsView(subscriptions)
+		humanizeSubscriptions(&view, time.Now(), locale)
+
+		if len(fields) > 0 {
+			records := subscriptionRecords(view, fields)
+
+			text, err := render(format, map[string]any{"data": records}, records, func() string { return recordsMarkdown(records, fields, locale) })
+			if err != nil {
+				return toolErrorResult(err, locale), nil
+			}
+
+			return mcp.NewToolResultText(text), nil
+		}
+
+		text, err := render(format, view, view.Data, func() string { return subscriptionsMarkdown(view, locale) })
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return mcp.NewToolResultText(text), nil
+	})
+
+	exportCSVTool := mcp.NewTool(
+		"Export-CSV",
+		mcp.WithDescription("Export your subscription history or the full catalog as CSV, ready to open in a spreadsheet. The catalog takes minutes, it returns a job_id to follow with Job-Status and get with Job-Result"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("dataset", mcp.Description("Data to export, courses is the full catalog with the duration of every course"), mcp.Enum(DatasetSubscriptions, DatasetCourses), mcp.DefaultString(DatasetSubscriptions)),
+		localeOption(),
+	)
+	tools.AddTool(exportCSVTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		dataset := args.String("dataset", DatasetSubscriptions, DatasetSubscriptions, DatasetCourses)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		if dataset == DatasetCourses {
+			// Fetching the curriculum of every course outlasts the
+			// timeout of most clients.
+			job, err := srv.jobs.Start(ctx, request, func(ctx context.Context, progress func(done, total int)) (*mcp.CallToolResult, error) {
+				courses, err := srv.catalog.Courses(ctx)
+				if err != nil {
+					return nil, err
+				}
+				view := newCoursesView(courses)
+				humanizeCourses(&view, time.Now(), locale)
+				enrichDurations(ctx, &view, srv.durations, progress)
+
+				text, err := coursesCSV(view)
+				if err != nil {
+					return nil, err
+				}
+				return mcp.NewToolResultText(text), nil
+			})
+			if err != nil {
+				return toolErrorResult(err, locale), nil
+			}
+			return jsonResult(job)
+		}
+
+		var subscriptions SubscriptionResponse
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			subscriptions, err = srv.client.Subscriptions.List(ctx, token)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		view := newSubscriptionsView(subscriptions)
+		humanizeSubscriptions(&view, time.Now(), locale)
+
+		text, err := subscriptionsCSV(view)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return mcp.NewToolResultText(text), nil
+	})
+
+	continueLearningTool := mcp.NewTool(
+		"Continue-Learning",
+		mcp.WithDescription("Get the last course and class you were watching with a link to resume it"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		localeOption(),
+	)
+	tools.AddTool(continueLearningTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var lastWatched LastWatchedResponse
+		var found bool
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			lastWatched, found, err = srv.client.Account.LastWatched(ctx, token)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		if !found {
+			return mcp.NewToolResultText(locale.T("nothing_watched")), nil
+		}
+
+		data := lastWatched.Data
+		continueLearning := ContinueLearning{
+			CourseID:       data.Course.ID,
+			CourseName:     data.Course.Name,
+			ClassID:        data.Class.ID,
+			ClassName:      data.Class.Name,
+			Progress:       data.Progress,
+			WatchedAt:      data.WatchedAt.Time,
+			WatchedAtHuman: relativeTime(time.Now(), data.WatchedAt.Time, locale),
+			URL:            classURL(data.Course.Slug, data.Class.Slug),
+		}
+
+		return jsonResult(continueLearning)
+	})
+
+	referralTool := mcp.NewTool(
+		"My-Referral-Link",
+		mcp.WithDescription("Get your EDteam referral link to share and how many people signed up or bought with it"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		localeOption(),
+	)
+	tools.AddTool(referralTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var referral ReferralResponse
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			referral, err = srv.client.Account.Referral(ctx, token)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(referral.Data)
+	})
+
+	whoamiTool := mcp.NewTool(
+		"Whoami",
+		mcp.WithDescription("Get who the tools act as: the name and masked email of the EDteam account, whether its subscription is active and when the session token expires. Call it first to check the account before other tools"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		localeOption(),
+	)
+	tools.AddTool(whoamiTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var profile ProfileResponse
+		var subscriptions SubscriptionResponse
+		var sessionToken string
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			sessionToken = token
+			profile, err = srv.client.Account.Profile(ctx, token)
+			if err != nil {
+				return err
+			}
+			subscriptions, err = srv.client.Subscriptions.List(ctx, token)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(whoami(profile.Data, subscriptions, sessionToken, time.Now(), locale))
+	})
+
+	sessionInfoTool := mcp.NewTool(
+		"Session-Info",
+		mcp.WithDescription("Inspect the EDteam session token without showing it: its issuer, expiry and scopes when it is a JWT and a fingerprint to tell tokens apart. Use it when the tools of the account start failing"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+		localeOption(),
+	)
+	tools.AddTool(sessionInfoTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		var sessionToken string
+		err := srv.session.Do(ctx, func(token string) error {
+			sessionToken = token
+			return nil
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(sessionInfo(sessionToken, cfg.MultiTenant, time.Now(), locale))
+	})
+
+	supportTicketTool := mcp.NewTool(
+		"Support-Ticket-Create",
+		mcp.WithDescription("Open a help request with EDteam support, e.g. for a failed payment or a course you can't access. The support team answers to your account email"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("category", mcp.Description("Kind of problem"), mcp.Enum(ticketCategories...), mcp.Required()),
+		mcp.WithString("subject", mcp.Description("One line summary of the problem"), mcp.Required()),
+		mcp.WithString("description", mcp.Description("What happened, what was expected and the steps already tried"), mcp.Required()),
+		mcp.WithString("order_id", mcp.Description("Order or payment ID, for billing problems")),
+		mcp.WithNumber("course_id", mcp.Description("Course ID, when the problem is about a course"), mcp.Min(1)),
+		localeOption(),
+	)
+	tools.AddTool(supportTicketTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		ticket := SupportTicket{
+			Category:    args.String("category", "", ticketCategories...),
+			Subject:     args.RequiredString("subject"),
+			Description: args.RequiredString("description"),
+			OrderID:     args.String("order_id", ""),
+			CourseID:    args.Int("course_id", 0, 1, MaxSafeInt),
+		}
+		args.RequiredString("category")
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var created SupportTicketResponse
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			created, err = srv.client.Account.CreateSupportTicket(ctx, token, ticket)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(created)
+	})
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// billingTools are the tools that buy courses and manage the billing details
+// of the account.
+func (srv *Server) billingTools(tools *toolSet, cfg Config) {
+	shoppingCartTool := mcp.NewTool(
+		"Shopping-Cart-Add-Course",
+		mcp.WithDescription("Add a course to your shopping cart"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithNumber("course_id", mcp.Description("Course ID"), mcp.Min(1), mcp.Required()),
+		localeOption(),
+	)
+	tools.AddTool(shoppingCartTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		courseID := args.RequiredInt("course_id", 1, MaxSafeInt)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		release, err := srv.reserveSpending(ctx, cfg, courseID)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		var shoppingCart ShoppingCartResponse
+		err = srv.session.Do(ctx, func(token string) (err error) {
+			shoppingCart, err = srv.client.Cart.Add(ctx, token, courseID)
+			return err
+		})
+		if err != nil {
+			releaseFailed(release, err)
+			return toolErrorResult(err, locale), nil
+		}
+
+		var shoppingCartRaw []byte
+		shoppingCartRaw, err = json.Marshal(shoppingCart)
+		if err != nil {
+			return nil, err
+		}
+
+		// Create a response
+		return mcp.NewToolResultText(string(shoppingCartRaw)), nil
+	})
+
+	giftCourseTool := mcp.NewTool(
+		"Gift-Course",
+		mcp.WithDescription("Buy a course as a gift for someone else, EDteam sends it to the recipient's email. This charges your account and can't be undone"),
+		mcp.WithTitleAnnotation("Gift a course"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithNumber("course_id", mcp.Description("Course ID"), mcp.Min(1), mcp.Required()),
+		mcp.WithString("recipient_email", mcp.Description("Email of the person receiving the course"), mcp.Pattern(emailPattern.String()), mcp.Required()),
+		localeOption(),
+	)
+	tools.AddTool(giftCourseTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		courseID := args.RequiredInt("course_id", 1, MaxSafeInt)
+		email := args.RequiredString("recipient_email")
+		args.Match("recipient_email", emailPattern, "an email address")
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		release, err := srv.reserveSpending(ctx, cfg, courseID)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		var gift GiftResponse
+		err = srv.session.Do(ctx, func(token string) (err error) {
+			gift, err = srv.client.Billing.Gift(ctx, token, courseID, email)
+			return err
+		})
+		if err != nil {
+			releaseFailed(release, err)
+			return toolErrorResult(err, locale), nil
+		}
+
+		giftRaw, err := json.Marshal(gift)
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(string(giftRaw)), nil
+	})
+
+	billingAddressGetTool := mcp.NewTool(
+		"Billing-Address-Get",
+		mcp.WithDescription("Get the billing details used in your invoices: name, tax ID and address"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		localeOption(),
+	)
+	tools.AddTool(billingAddressGetTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var address BillingAddressResponse
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			address, err = srv.client.Billing.Address(ctx, token)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(address.Data)
+	})
+
+	billingAddressUpdateTool := mcp.NewTool(
+		"Billing-Address-Update",
+		mcp.WithDescription("Update the billing details used in your invoices. Only the fields sent are changed, the rest keep their current value"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("name", mcp.Description("Name or company name in the invoice")),
+		mcp.WithString("tax_id", mcp.Description("Tax ID, e.g. RFC, RUC, NIT or CUIT")),
+		mcp.WithString("address", mcp.Description("Street and number")),
+		mcp.WithString("city", mcp.Description("City")),
+		mcp.WithString("state", mcp.Description("State or province")),
+		mcp.WithString("postal_code", mcp.Description("Postal code")),
+		mcp.WithString("country", mcp.Description("ISO 3166-1 alpha-2 country code, e.g. PE"), mcp.Pattern(countryPattern.String())),
+		localeOption(),
+	)
+	tools.AddTool(billingAddressUpdateTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		changes := BillingAddress{
+			Name:       args.String("name", ""),
+			TaxID:      args.String("tax_id", ""),
+			Address:    args.String("address", ""),
+			City:       args.String("city", ""),
+			State:      args.String("state", ""),
+			PostalCode: args.String("postal_code", ""),
+			Country:    args.Match("country", countryPattern, "an ISO 3166-1 alpha-2 country code"),
+		}
+		args.RequireAny("name", "tax_id", "address", "city", "state", "postal_code", "country")
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var address BillingAddressResponse
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			current, err := srv.client.Billing.Address(ctx, token)
+			if err != nil {
+				return err
+			}
+			address, err = srv.client.Billing.UpdateAddress(ctx, token, mergeBillingAddress(current.Data, changes))
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(address.Data)
+	})
+
+	paymentMethodsTool := mcp.NewTool(
+		"Payment-Methods",
+		mcp.WithDescription("List your saved payment methods with masked numbers. The default one is charged at checkout"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		localeOption(),
+	)
+	tools.AddTool(paymentMethodsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var methods PaymentMethodsResponse
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			methods, err = srv.client.Billing.PaymentMethods(ctx, token)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(maskPaymentMethods(methods))
+	})
+}
+
+// reserveSpending holds the price of a course against the spending cap of
+// the caller before buying it. The cap is kept by callerKey, like the rate
+// limits, so reconnecting doesn't reset it.
+func (srv *Server) reserveSpending(ctx context.Context, cfg Config, courseID int) (release func(), err error) {
+	if cfg.SpendingCap.Amount == 0 {
+		return func() {}, nil
+	}
+	courses, err := srv.catalog.Courses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i := courseIndex(courses, courseID)
+	if i < 0 {
+		return nil, &ArgumentError{Argument: "course_id", Reason: fmt.Sprintf("the course %d is not in the catalog, its price can't be checked against the spending cap", courseID)}
+	}
+	price, err := priceIn(courses.Data[i], cfg.SpendingCap.Currency, cfg.CurrencyCodes, srv.rates)
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.spending.Reserve(callerKey(ctx), price, cfg.SpendingCap)
+}
+
+// releaseFailed takes back the reservation of a purchase that failed. An
+// *AmbiguousError may have bought the course anyway, so it keeps counting
+// toward the cap and retrying can't go past it.
+func releaseFailed(release func(), err error) {
+	var ambiguousErr *AmbiguousError
+	if errors.As(err, &ambiguousErr) {
+		return
+	}
+
+	release()
+}
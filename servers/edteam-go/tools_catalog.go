@@ -0,0 +1,305 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// catalogTools are the tools that browse and compare the courses of the
+// catalog.
+func (srv *Server) catalogTools(tools *toolSet, cfg Config) {
+	coursesListTool := mcp.NewTool(
+		"Courses-List",
+		mcp.WithDescription("List all courses of EDteam"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithNumber("page", mcp.Description("Page number"), mcp.DefaultNumber(1), mcp.Min(1)),
+		mcp.WithString("cursor", mcp.Description("next_cursor of the previous page, it replaces page and limit")),
+		mcp.WithNumber(
+			"limit",
+			mcp.Description(fmt.Sprintf("Limit number of courses, defaults to %d and can't be greater than %d", cfg.DefaultPageSize, cfg.MaxPageSize)),
+			mcp.DefaultNumber(float64(cfg.DefaultPageSize)),
+			mcp.Min(1),
+			mcp.Max(float64(cfg.MaxPageSize)),
+		),
+		mcp.WithString("currency", mcp.Description("ISO 4217 code to convert all prices into, e.g. USD"), mcp.Pattern(currencyPattern.String())),
+		mcp.WithString("professor", mcp.Description("Search the whole catalog for the courses of a professor by first name, last name or nickname; send it again with the cursor of the next page")),
+		mcp.WithString("sort", mcp.Description("Sort the whole catalog, or every match when searching by professor, before it is split in pages; send it again with the cursor of the next page"), mcp.Enum(sortOptions...)),
+		mcp.WithString("verbosity", mcp.Description("compact returns only id, name, slug, level, price and professors, and the converted price when a currency is given; full returns every field, including the number of classes and the duration"), mcp.Enum(VerbosityCompact, VerbosityFull), mcp.DefaultString(VerbosityCompact)),
+		fieldsOption(courseFields),
+		mcp.WithString("format", mcp.Description("Output format, markdown returns a compact table and jsonl one course per line"), mcp.Enum(formats...), mcp.DefaultString(FormatJSON)),
+		localeOption(),
+	)
+	tools.AddTool(coursesListTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		page := args.Int("page", 1, 1, MaxSafeInt)
+		limit := args.Int("limit", cfg.DefaultPageSize, 1, cfg.MaxPageSize)
+		currency := strings.ToUpper(args.Match("currency", currencyPattern, "an ISO 4217 code"))
+		sortBy := args.String("sort", "", sortOptions...)
+		professor := args.String("professor", "")
+		verbosity := args.String("verbosity", VerbosityCompact, VerbosityCompact, VerbosityFull)
+		fields := args.Strings("fields", courseFields)
+		format := args.String("format", FormatJSON, formats...)
+		page, limit = args.Cursor(page, limit, cfg.MaxPageSize)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		if len(fields) == 0 && verbosity != VerbosityFull {
+			fields = compactFields(currency)
+		}
+
+		// Searching and sorting need the whole catalog, they are paginated
+		// here from the cached copy.
+		local := professor != "" || sortBy != ""
+		var courses CourseResponse
+		var err error
+		if local {
+			courses, err = srv.catalog.Courses(ctx)
+			if professor != "" {
+				courses = filterByProfessor(courses, professor)
+			}
+		} else {
+			courses, err = srv.client.Courses.List(ctx, uint(page), uint(limit))
+		}
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		view := newCoursesView(courses)
+		if err := applyCurrencies(&view, cfg.CurrencyCodes, srv.rates, currency); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		humanizeCourses(&view, time.Now(), locale)
+		if err := sortCourses(&view, sortBy, srv.rates); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		var pagination Pagination
+		if local {
+			total := len(view.Data)
+			view.Data = pageOf(view.Data, page, limit)
+			pagination = localPagination(page, limit, total, locale)
+		} else {
+			pagination = paginate(ctx, srv.client.Courses, page, limit, len(view.Data), locale)
+		}
+
+		if verbosity == VerbosityFull || containsAny(fields, durationFields) {
+			enrichDurations(ctx, &view, srv.durations, nil)
+		}
+
+		if len(fields) > 0 {
+			records := courseRecords(view, fields)
+
+			text, err := renderPage(format, records, pagination, func() string { return recordsMarkdown(records, fields, locale) }, locale)
+			if err != nil {
+				return toolErrorResult(err, locale), nil
+			}
+
+			return mcp.NewToolResultText(text), nil
+		}
+
+		text, err := renderPage(format, view.Data, pagination, func() string { return coursesMarkdown(view, locale) }, locale)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		// Create a response
+		return mcp.NewToolResultText(text), nil
+	})
+
+	courseAccessTool := mcp.NewTool(
+		"Course-Access",
+		mcp.WithDescription("Tell whether you can watch a course: free, owned because you bought it, included in your active subscription or requires a purchase"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithNumber("course_id", mcp.Description("Course ID"), mcp.Min(1), mcp.Required()),
+		localeOption(),
+	)
+	tools.AddTool(courseAccessTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		courseID := args.RequiredInt("course_id", 1, MaxSafeInt)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		courses, err := srv.catalog.Courses(ctx)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		i := courseIndex(courses, courseID)
+		if i < 0 {
+			return mcp.NewToolResultError(locale.T("course_not_found", courseID)), nil
+		}
+
+		var owned OwnedCoursesResponse
+		var subscriptions SubscriptionResponse
+		err = srv.session.Do(ctx, func(token string) (err error) {
+			owned, err = srv.client.Account.OwnedCourses(ctx, token)
+			if err != nil {
+				return err
+			}
+			subscriptions, err = srv.client.Subscriptions.List(ctx, token)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(courseAccess(courses, i, owned, subscriptions, cfg.CurrencyCodes, time.Now(), locale))
+	})
+
+	courseFAQTool := mcp.NewTool(
+		"Course-FAQ",
+		mcp.WithDescription("Get the frequently asked questions of a course page, where the refund policy, prerequisites and certificate details usually are"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithNumber("course_id", mcp.Description("Course ID"), mcp.Min(1), mcp.Required()),
+		localeOption(),
+	)
+	tools.AddTool(courseFAQTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		courseID := args.RequiredInt("course_id", 1, MaxSafeInt)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		courses, err := srv.catalog.Courses(ctx)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		i := courseIndex(courses, courseID)
+		if i < 0 {
+			return mcp.NewToolResultError(locale.T("course_not_found", courseID)), nil
+		}
+
+		faq, err := srv.client.Courses.FAQ(ctx, courses.Data[i].Course.Slug)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(faq.Data)
+	})
+
+	coursePreviewTool := mcp.NewTool(
+		"Course-Preview",
+		mcp.WithDescription("Get the trailer of a course and the classes you can watch for free before buying it"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithNumber("course_id", mcp.Description("Course ID"), mcp.Min(1), mcp.Required()),
+		localeOption(),
+	)
+	tools.AddTool(coursePreviewTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		courseID := args.RequiredInt("course_id", 1, MaxSafeInt)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		courses, err := srv.catalog.Courses(ctx)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		i := courseIndex(courses, courseID)
+		if i < 0 {
+			return mcp.NewToolResultError(locale.T("course_not_found", courseID)), nil
+		}
+		course := courses.Data[i].Course
+
+		trailer, curriculum, err := getTrailerAndCurriculum(ctx, srv.client.Courses, course.Slug)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(coursePreview(course.ID, course.Name, course.Slug, trailer, curriculum))
+	})
+
+	courseDetailsTool := mcp.NewTool(
+		"Courses-Details",
+		mcp.WithDescription(fmt.Sprintf("Get the details of up to %d courses at once, with their prices, professors, duration, trailer and curriculum. Use it to compare courses instead of calling a tool per course", maxCourseDetails)),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithArray("course_ids", mcp.Description("IDs of the courses"), mcp.Items(map[string]any{"type": "number"})),
+		mcp.WithArray("slugs", mcp.Description("Slugs of the courses, e.g. go-desde-cero"), mcp.Items(map[string]any{"type": "string", "pattern": slugPattern.String()})),
+		localeOption(),
+	)
+	tools.AddTool(courseDetailsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		args.RequireAny("course_ids", "slugs")
+		courseIDs := args.Ints("course_ids", 1, MaxSafeInt)
+		slugs := args.MatchStrings("slugs", slugPattern, "a course slug like go-desde-cero")
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		if len(courseIDs)+len(slugs) > maxCourseDetails {
+			err := &ArgumentError{Argument: "course_ids, slugs", Reason: fmt.Sprintf("at most %d courses are allowed, got %d", maxCourseDetails, len(courseIDs)+len(slugs))}
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		courses, err := srv.catalog.Courses(ctx)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(courseDetails(ctx, srv.client.Courses, courses, courseIDs, slugs, time.Now(), locale))
+	})
+
+	studyPlanTool := mcp.NewTool(
+		"Generate-Study-Plan",
+		mcp.WithDescription("Build a week by week study plan for a goal with the weekly hours you can study, using the duration of the classes of the courses"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("goal", mcp.Description("What you want to learn, e.g. backend development with Go"), mcp.Required()),
+		mcp.WithNumber("weekly_hours", mcp.Description("Hours per week you can study"), mcp.Min(1), mcp.Max(80), mcp.Required()),
+		mcp.WithString("deadline", mcp.Description("Date to finish the plan, YYYY-MM-DD")),
+		mcp.WithArray("course_ids", mcp.Description("Courses to include, in order; recommended from the goal when empty"), mcp.Items(map[string]any{"type": "number"})),
+		mcp.WithNumber("max_courses", mcp.Description("Maximum number of recommended courses"), mcp.DefaultNumber(3), mcp.Min(1), mcp.Max(10)),
+		localeOption(),
+	)
+	tools.AddTool(studyPlanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		goal := args.RequiredString("goal")
+		weeklyHours := args.RequiredInt("weekly_hours", 1, 80)
+		deadline := args.Date("deadline")
+		courseIDs := args.Ints("course_ids", 1, MaxSafeInt)
+		maxCourses := args.Int("max_courses", 3, 1, 10)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		courses, err := srv.catalog.Courses(ctx)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		indexes := recommendCourses(courses, goal, maxCourses)
+		if len(courseIDs) > 0 {
+			indexes = indexes[:0]
+			for _, courseID := range courseIDs {
+				i := courseIndex(courses, courseID)
+				if i < 0 {
+					return mcp.NewToolResultError(locale.T("course_not_found", courseID)), nil
+				}
+				indexes = append(indexes, i)
+			}
+		}
+
+		now := time.Now()
+		start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		plan, err := buildStudyPlan(ctx, srv.client.Courses, courses, indexes, goal, weeklyHours, start, deadline, locale)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(plan)
+	})
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// communityTools are the tools that read and post in the community of the
+// courses.
+func (srv *Server) communityTools(tools *toolSet, cfg Config) {
+	communityThreadsTool := mcp.NewTool(
+		"Community-Threads",
+		mcp.WithDescription("List the discussion threads and questions of the community of a course, newest first"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithNumber("course_id", mcp.Description("Course ID"), mcp.Min(1), mcp.Required()),
+		mcp.WithNumber("page", mcp.Description("Page number"), mcp.Min(1), mcp.DefaultNumber(1)),
+		mcp.WithNumber("limit", mcp.Description("Threads per page"), mcp.Min(1), mcp.Max(float64(cfg.MaxPageSize)), mcp.DefaultNumber(float64(cfg.DefaultPageSize))),
+		localeOption(),
+	)
+	tools.AddTool(communityThreadsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		courseID := args.RequiredInt("course_id", 1, MaxSafeInt)
+		page := args.Int("page", 1, 1, MaxSafeInt)
+		limit := args.Int("limit", cfg.DefaultPageSize, 1, cfg.MaxPageSize)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var threads ThreadsResponse
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			threads, err = srv.client.Community.Threads(ctx, token, courseID, page, limit)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(threads.Data)
+	})
+
+	communityThreadTool := mcp.NewTool(
+		"Community-Thread-Read",
+		mcp.WithDescription("Read a community thread with its question and all the answers"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithNumber("thread_id", mcp.Description("Thread ID, from Community-Threads"), mcp.Min(1), mcp.Required()),
+		localeOption(),
+	)
+	tools.AddTool(communityThreadTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		threadID := args.RequiredInt("thread_id", 1, MaxSafeInt)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var thread ThreadResponse
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			thread, err = srv.client.Community.Thread(ctx, token, threadID)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(thread.Data)
+	})
+
+	communityPostTool := mcp.NewTool(
+		"Community-Question-Post",
+		mcp.WithDescription("Post a question in the community of a course under your name. The user is asked to approve it before it is published"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithNumber("course_id", mcp.Description("Course ID"), mcp.Min(1), mcp.Required()),
+		mcp.WithString("title", mcp.Description("Short title of the question"), mcp.Required()),
+		mcp.WithString("question", mcp.Description("The question, with the context other students need to answer it"), mcp.Required()),
+		mcp.WithBoolean("confirm", mcp.Description("Set to true once the user agreed to publish the question, only used by clients that can't ask the user directly"), mcp.DefaultBool(false)),
+		localeOption(),
+	)
+	tools.AddTool(communityPostTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		courseID := args.RequiredInt("course_id", 1, MaxSafeInt)
+		title := args.RequiredString("title")
+		question := args.RequiredString("question")
+		confirm := args.Bool("confirm", false)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		message := locale.T("confirm_question", title, courseID)
+		confirmation, err := confirmAction(ctx, srv.mcp, message, confirm)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		if confirmation != ConfirmationAccepted {
+			return confirmationResult(confirmation, message, locale), nil
+		}
+
+		var thread ThreadResponse
+		err = srv.session.Do(ctx, func(token string) (err error) {
+			thread, err = srv.client.Community.PostQuestion(ctx, token, courseID, title, question)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(thread.Data)
+	})
+
+	reviewSubmitTool := mcp.NewTool(
+		"Course-Review-Submit",
+		mcp.WithDescription("Rate a course you took and leave a review. The user is asked to approve it before it is published"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithNumber("course_id", mcp.Description("Course ID"), mcp.Min(1), mcp.Required()),
+		mcp.WithNumber("rating", mcp.Description("Stars from 1 to 5"), mcp.Min(1), mcp.Max(5), mcp.Required()),
+		mcp.WithString("text", mcp.Description("What you liked and what could be better"), mcp.Required()),
+		mcp.WithBoolean("confirm", mcp.Description("Set to true once the user agreed to publish the review, only used by clients that can't ask the user directly"), mcp.DefaultBool(false)),
+		localeOption(),
+	)
+	tools.AddTool(reviewSubmitTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		courseID := args.RequiredInt("course_id", 1, MaxSafeInt)
+		rating := args.RequiredInt("rating", 1, 5)
+		text := args.RequiredString("text")
+		confirm := args.Bool("confirm", false)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		message := locale.T("confirm_review", rating, courseID)
+		confirmation, err := confirmAction(ctx, srv.mcp, message, confirm)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		if confirmation != ConfirmationAccepted {
+			return confirmationResult(confirmation, message, locale), nil
+		}
+
+		var review ReviewResponse
+		err = srv.session.Do(ctx, func(token string) (err error) {
+			review, err = srv.client.Community.SubmitReview(ctx, token, courseID, rating, text)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(review)
+	})
+}
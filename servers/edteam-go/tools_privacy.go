@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// privacyTools are the requests about the data of the account, both need the
+// approval of the user.
+func (srv *Server) privacyTools(tools *toolSet, cfg Config) {
+	accountExportTool := mcp.NewTool(
+		"Account-Export-Data",
+		mcp.WithDescription("Ask EDteam for a copy of all your account data, the download link is sent to your account email. The user is asked to approve it first"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithBoolean("confirm", mcp.Description("Set to true once the user agreed to the request, only used by clients that can't ask the user directly"), mcp.DefaultBool(false)),
+		localeOption(),
+	)
+	tools.AddTool(accountExportTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		confirm := args.Bool("confirm", false)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		accountEmail := cfg.Email
+		if cfg.MultiTenant {
+			accountEmail = locale.T("account_email")
+		}
+		message := locale.T("confirm_export", accountEmail)
+		confirmation, err := confirmAction(ctx, srv.mcp, message, confirm)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		if confirmation != ConfirmationAccepted {
+			return confirmationResult(confirmation, message, locale), nil
+		}
+
+		var privacy PrivacyRequestResponse
+		err = srv.session.Do(ctx, func(token string) (err error) {
+			privacy, err = srv.client.Account.RequestDataExport(ctx, token)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(privacy)
+	})
+
+	accountDeleteTool := mcp.NewTool(
+		"Account-Delete-Request",
+		mcp.WithDescription("Ask EDteam to delete your account and all its data. This can't be undone: courses, certificates and the subscription are lost. The user must type the account email and approve it"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("confirm_email", mcp.Description("The account email typed by the user, to make sure the right account is deleted"), mcp.Required()),
+		mcp.WithBoolean("confirm", mcp.Description("Set to true once the user agreed to the deletion, only used by clients that can't ask the user directly"), mcp.DefaultBool(false)),
+		localeOption(),
+	)
+	tools.AddTool(accountDeleteTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		email := args.RequiredString("confirm_email")
+		confirm := args.Bool("confirm", false)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		if !strings.EqualFold(email, cfg.Email) {
+			return mcp.NewToolResultError(locale.T("confirm_email_mismatch")), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		message := locale.T("confirm_delete", cfg.Email)
+		confirmation, err := confirmAction(ctx, srv.mcp, message, confirm)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		if confirmation != ConfirmationAccepted {
+			return confirmationResult(confirmation, message, locale), nil
+		}
+
+		var privacy PrivacyRequestResponse
+		err = srv.session.Do(ctx, func(token string) (err error) {
+			privacy, err = srv.client.Account.RequestDeletion(ctx, token)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(privacy)
+	})
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// serverTools are the tools about the server itself: its jobs, its help and
+// its config.
+func (srv *Server) serverTools(tools *toolSet, cfg Config) {
+	jobStatusTool := mcp.NewTool(
+		"Job-Status",
+		mcp.WithDescription("Get the status and the progress of a job started by another tool, like the catalog export of Export-CSV"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithString("job_id", mcp.Description("job_id returned by the tool that started the job"), mcp.Required()),
+		localeOption(),
+	)
+	tools.AddTool(jobStatusTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		id := args.RequiredString("job_id")
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		job, _, err := srv.jobs.Get(ctx, id)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(job)
+	})
+
+	jobResultTool := mcp.NewTool(
+		"Job-Result",
+		mcp.WithDescription("Get the result of a finished job, while it is running it returns the status like Job-Status. Results are kept for one hour"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithString("job_id", mcp.Description("job_id returned by the tool that started the job"), mcp.Required()),
+		localeOption(),
+	)
+	tools.AddTool(jobResultTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		id := args.RequiredString("job_id")
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		job, result, err := srv.jobs.Get(ctx, id)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		if job.Status == JobFailed {
+			return mcp.NewToolResultError(locale.T("job_failed", job.Error)), nil
+		}
+		if job.Status != JobDone {
+			return jsonResult(job)
+		}
+
+		return result, nil
+	})
+
+	helpTool := mcp.NewTool(
+		"Help",
+		mcp.WithDescription("Describe the tools of this server with examples of their arguments and the common workflows, like browse, compare and add to the cart. Call it first when unsure which tool to use"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithString("tool", mcp.Description("Only describe this tool and the workflows that use it")),
+		localeOption(),
+	)
+	tools.AddTool(helpTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		tool := args.String("tool", "")
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		exposed := srv.mcp.ListTools()
+		if _, ok := exposed[tool]; tool != "" && !ok {
+			return mcp.NewToolResultError(locale.T("unknown_tool", tool)), nil
+		}
+
+		return jsonResult(help(exposed, tool, locale))
+	})
+
+	policyTool := mcp.NewTool(
+		"Policy",
+		mcp.WithDescription("Get the rules set by the operator of this server: the spending cap of an account, the tools that ask the user before running and what data the server keeps. Follow them when acting for the user"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+		localeOption(),
+	)
+	tools.AddTool(policyTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(policy(cfg, srv.mcp.ListTools(), locale))
+	})
+
+	versionTool := mcp.NewTool(
+		"Version",
+		mcp.WithDescription("Get the version of this server, the mcp-go version it was built with and a summary of its configuration, useful to report a problem"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+	)
+	tools.AddTool(versionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return jsonResult(VersionInfo{BuildInfo: buildInfo(), Config: configSummary(cfg)})
+	})
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// teamTools are the tools of the admins of a business plan.
+func (srv *Server) teamTools(tools *toolSet, cfg Config) {
+	teamMembersTool := mcp.NewTool(
+		"Team-Members",
+		mcp.WithDescription("List the members of your EDteam business plan and the course seats assigned to each one. Only for plan admins"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		localeOption(),
+	)
+	tools.AddTool(teamMembersTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var members TeamMembersResponse
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			members, err = srv.client.Business.Members(ctx, token)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(members)
+	})
+
+	teamSeatAssignTool := mcp.NewTool(
+		"Team-Seat-Assign",
+		mcp.WithDescription("Assign a course seat of your business plan to a team member. Only for plan admins"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithNumber("member_id", mcp.Description("Team member ID, from Team-Members"), mcp.Min(1), mcp.Required()),
+		mcp.WithNumber("course_id", mcp.Description("Course ID"), mcp.Min(1), mcp.Required()),
+		localeOption(),
+	)
+	tools.AddTool(teamSeatAssignTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		memberID := args.RequiredInt("member_id", 1, MaxSafeInt)
+		courseID := args.RequiredInt("course_id", 1, MaxSafeInt)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var seat SeatResponse
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			seat, err = srv.client.Business.AssignSeat(ctx, token, memberID, courseID)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(seat)
+	})
+
+	teamSeatRevokeTool := mcp.NewTool(
+		"Team-Seat-Revoke",
+		mcp.WithDescription("Take a course seat back from a team member, the seat becomes available to assign again. Only for plan admins"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithNumber("member_id", mcp.Description("Team member ID, from Team-Members"), mcp.Min(1), mcp.Required()),
+		mcp.WithNumber("course_id", mcp.Description("Course ID"), mcp.Min(1), mcp.Required()),
+		localeOption(),
+	)
+	tools.AddTool(teamSeatRevokeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		memberID := args.RequiredInt("member_id", 1, MaxSafeInt)
+		courseID := args.RequiredInt("course_id", 1, MaxSafeInt)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var seat SeatResponse
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			seat, err = srv.client.Business.RevokeSeat(ctx, token, memberID, courseID)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(seat)
+	})
+
+	teamMemberProgressTool := mcp.NewTool(
+		"Team-Member-Progress",
+		mcp.WithDescription("Get the progress of a team member in each course they have a seat in. Only for plan admins"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithNumber("member_id", mcp.Description("Team member ID, from Team-Members"), mcp.Min(1), mcp.Required()),
+		localeOption(),
+	)
+	tools.AddTool(teamMemberProgressTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		memberID := args.RequiredInt("member_id", 1, MaxSafeInt)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var progress MemberProgressResponse
+		err := srv.session.Do(ctx, func(token string) (err error) {
+			progress, err = srv.client.Business.MemberProgress(ctx, token, memberID)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(progress)
+	})
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// updatesTools are the tools that follow the changes of the catalog: new
+// courses, prices and sales.
+func (srv *Server) updatesTools(tools *toolSet, cfg Config) {
+	whatsNewTool := mcp.NewTool(
+		"Whats-New",
+		mcp.WithDescription("List the courses and blog articles published since a date or, without a date, since the last time you asked"),
+		// Every call saves the snapshot the next one compares with and
+		// takes the pending sales of the srv.watchlist. Only the server
+		// changes, like with Watch-Course.
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithString("since", mcp.Description("Date to look from, YYYY-MM-DD. Defaults to the last call to this tool")),
+		localeOption(),
+	)
+	tools.AddTool(whatsNewTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		since := args.Date("since")
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		courses, err := srv.catalog.Courses(ctx)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		posts, err := srv.client.Content.BlogPosts(ctx)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		path := snapshotPath(cfg.DataDir)
+		previous, found, err := loadSnapshot(path)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		var last *Snapshot
+		if found {
+			last = &previous
+		}
+		result := whatsNew(courses, posts, since, last, now, locale)
+		if err := saveSnapshot(path, takeSnapshot(courses, posts, now)); err != nil {
+			return nil, err
+		}
+		if _, err := srv.watchlist.CheckSales(courses, cfg.CurrencyCodes, now); err != nil {
+			return nil, err
+		}
+		result.Sales, err = srv.watchlist.TakePending()
+		if err != nil {
+			return nil, err
+		}
+
+		return jsonResult(result)
+	})
+
+	catalogDiffTool := mcp.NewTool(
+		"Catalog-Diff",
+		mcp.WithDescription("Compare the catalog cached by the server with the live EDteam catalog: the courses added, removed and changed, like a new price or level. The cache is replaced with the live catalog"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		localeOption(),
+	)
+	tools.AddTool(catalogDiffTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		cached, cachedAt, live, err := srv.catalog.Refresh(ctx)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		return jsonResult(catalogDiff(cached, cachedAt, live, cfg.CurrencyCodes, time.Now(), locale))
+	})
+
+	priceHistoryTool := mcp.NewTool(
+		"Price-History",
+		mcp.WithDescription("Show how the price of a course changed over time, to decide whether to buy now or wait for a sale. Prices are recorded every time the catalog is fetched"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithNumber("course_id", mcp.Description("Course ID"), mcp.Min(1), mcp.Required()),
+		mcp.WithString("currency", mcp.Description("Only the prices in this ISO 4217 currency, e.g. USD"), mcp.Pattern(currencyPattern.String())),
+		localeOption(),
+	)
+	tools.AddTool(priceHistoryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		courseID := args.RequiredInt("course_id", 1, MaxSafeInt)
+		currency := strings.ToUpper(args.Match("currency", currencyPattern, "an ISO 4217 code"))
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		// Fetching the catalog records the current prices when the cached
+		// copy expired.
+		courses, err := srv.catalog.Courses(ctx)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		i := courseIndex(courses, courseID)
+		if i < 0 {
+			return mcp.NewToolResultError(locale.T("course_not_found", courseID)), nil
+		}
+
+		history, err := srv.prices.History(ctx, courseID, cfg.CurrencyCodes)
+		if err != nil {
+			return nil, err
+		}
+
+		return jsonResult(priceSummary(courseID, courses.Data[i].Course.Name, history, currency, locale))
+	})
+
+	watchCourseTool := mcp.NewTool(
+		"Watch-Course",
+		mcp.WithDescription("Watch a course to be told when it goes on sale, stop watching it or list the watched courses. Sales are notified by the server and listed by Whats-New"),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithString("action", mcp.Description("What to do"), mcp.Enum(watchActions...), mcp.DefaultString(WatchAdd)),
+		mcp.WithNumber("course_id", mcp.Description("Course ID, required to watch or unwatch"), mcp.Min(1)),
+		localeOption(),
+	)
+	tools.AddTool(watchCourseTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		action := args.String("action", WatchAdd, watchActions...)
+		courseID := 0
+		if action != WatchList {
+			courseID = args.RequiredInt("course_id", 1, MaxSafeInt)
+		}
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		var watches []Watch
+		var err error
+		switch action {
+		case WatchList:
+			watches, err = srv.watchlist.List()
+		case WatchRemove:
+			watches, err = srv.watchlist.Remove(courseID)
+		default:
+			courses, err := srv.catalog.Courses(ctx)
+			if err != nil {
+				return toolErrorResult(err, locale), nil
+			}
+			i := courseIndex(courses, courseID)
+			if i < 0 {
+				return mcp.NewToolResultError(locale.T("course_not_found", courseID)), nil
+			}
+			watches, err = srv.watchlist.Add(courseID, courses.Data[i].Course.Name, time.Now())
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return jsonResult(watches)
+	})
+
+	calendarTool := mcp.NewTool(
+		"Calendar-ICS",
+		mcp.WithDescription("Export the upcoming EDteam live classes and the end date of your subscription as an iCalendar (.ics) file to import in any calendar app"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		localeOption(),
+	)
+	tools.AddTool(calendarTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := NewArgs(request.GetArguments())
+		locale := args.Locale(cfg.Locale)
+		if err := args.Err(); err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		ctx = WithLocale(ctx, locale)
+
+		events, err := srv.client.Content.LiveEvents(ctx)
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+		var subscriptions SubscriptionResponse
+		err = srv.session.Do(ctx, func(token string) (err error) {
+			subscriptions, err = srv.client.Subscriptions.List(ctx, token)
+			return err
+		})
+		if err != nil {
+			return toolErrorResult(err, locale), nil
+		}
+
+		ics := calendarICS(events, subscriptions, time.Now(), locale)
+
+		return mcp.NewToolResultResource(locale.T("calendar_ready"), mcp.TextResourceContents{
+			URI:      "edteam://calendar.ics",
+			MIMEType: "text/calendar",
+			Text:     ics,
+		}), nil
+	})
+}
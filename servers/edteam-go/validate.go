@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	"edteam-mcp/pkg/edteam"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// publicTools only call the public EDteam endpoints, they work without
+// EMAIL and PASSWORD. Keep it in sync with the tools that don't use the
+// session.
+var publicTools = []string{
+	"Courses-List",
+	"Generate-Study-Plan",
+	"Course-FAQ",
+	"Course-Preview",
+	"Courses-Details",
+	"Whats-New",
+	"Catalog-Diff",
+	"Price-History",
+	"Watch-Course",
+	"Help",
+	"Policy",
+	"Version",
+	"Job-Status",
+	"Job-Result",
+}
+
+// validateConfig checks the settings that LoadConfig can't check on its
+// own: the credentials needed by the exposed tools, the URLs and the listen
+// address. It runs at startup, before logging in, and before a reloaded
+// config replaces the current one.
+func validateConfig(cfg Config, tools []server.ServerTool) error {
+	var problems ConfigError
+
+	if !cfg.MultiTenant && (cfg.Email == "" || cfg.Password == "") {
+		var private []string
+		for _, tool := range tools {
+			if !contains(publicTools, tool.Tool.Name) {
+				private = append(private, tool.Tool.Name)
+			}
+		}
+		if len(private) > 0 {
+			problems.add(fmt.Errorf("EMAIL and PASSWORD must be set, %d tools use the EDteam account (like %s); expose only public tools with ENABLED_TOOLS to run without them", len(private), private[0]))
+		}
+	}
+
+	if source := cfg.CurrencyRates; strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		if u, err := url.Parse(source); err != nil || u.Host == "" {
+			problems.add(fmt.Errorf("CURRENCY_RATES %q is not a valid URL", source))
+		} else if !cfg.Telemetry {
+			problems.add(checkEgress("CURRENCY_RATES", source))
+		}
+	} else if source != "" {
+		if _, err := os.Stat(source); err != nil {
+			problems.add(fmt.Errorf("CURRENCY_RATES file: %w", err))
+		}
+	}
+
+	if cfg.EDteamBaseURL != "" {
+		if u, err := url.Parse(cfg.EDteamBaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+			problems.add(fmt.Errorf("EDTEAM_BASE_URL %q is not a valid URL, use a URL like http://localhost:8080", cfg.EDteamBaseURL))
+		} else if !cfg.Telemetry && !edteam.IsHost(u.Hostname()) {
+			problems.add(fmt.Errorf("EDTEAM_BASE_URL %q is not an EDteam URL and TELEMETRY is off", cfg.EDteamBaseURL))
+		}
+	}
+
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" {
+			problems.add(fmt.Errorf("ALLOWED_ORIGINS has %q, use origins like https://app.example.com", origin))
+		}
+	}
+
+	if cfg.DataDir != "" {
+		if err := os.MkdirAll(cfg.DataDir, 0o700); err != nil {
+			problems.add(fmt.Errorf("DATA_DIR is not usable: %w", err))
+		}
+	}
+
+	if cfg.Transport != TransportStdio {
+		host, _, err := net.SplitHostPort(cfg.Listen)
+		switch {
+		case err != nil:
+			problems.add(fmt.Errorf("LISTEN %q must be an address like 127.0.0.1:8080: %w", cfg.Listen, err))
+		case cfg.APIKey == "" && !isLoopback(host):
+			// Without an API key anyone reaching the address could use the
+			// account, so only this machine may.
+			problems.add(fmt.Errorf("LISTEN %q accepts connections from other hosts, set API_KEY or listen on 127.0.0.1", cfg.Listen))
+		}
+	}
+
+	return problems.err()
+}
+
+// checkListen fails when address is already in use, which would otherwise
+// only show up after logging in. It only runs at startup, once serving the
+// address is in use by the server itself.
+func checkListen(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("LISTEN %q is not available: %w", address, err)
+	}
+
+	return listener.Close()
+}
@@ -0,0 +1,306 @@
+// Package vcr records the HTTP traffic with EDteam into cassette files and
+// replays it later, so the tests and the offline mode work with real
+// payloads without credentials or network. Secrets are removed before
+// anything is written.
+package vcr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	ModeRecord = "record"
+	ModeReplay = "replay"
+)
+
+// Redacted replaces the secrets in the cassettes.
+const Redacted = "REDACTED"
+
+// sensitiveHeaders are replaced by Redacted in the requests and responses
+// written to a cassette.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "X-Edteam-Token"}
+
+// sensitiveFields are the JSON fields replaced by Redacted in the request
+// and response bodies, at any depth. They are compared ignoring the case,
+// the underscores and the dashes, so tax_id also matches taxId.
+var sensitiveFields = []string{
+	// Credentials.
+	"password", "token", "access_token", "refresh_token",
+	// The people: accounts, team members, students and gift recipients.
+	"email", "buyer", "recipient_email", "firstname", "lastname", "first_name", "last_name", "full_name", "nickname", "phone",
+	// Invoices.
+	"tax_id", "postal_code", "zip",
+	// Payment methods.
+	"last4", "expires", "expiry", "card_number", "cvc", "cvv", "holder", "cardholder", "iban",
+}
+
+// addressFields are redacted only in the objects that hold an address, the
+// ones with one of addressMarkers. Elsewhere a name is the one of a course
+// and a state the one of a subscription.
+var (
+	addressFields  = []string{"name", "address", "street", "city", "state"}
+	addressMarkers = []string{"tax_id", "postal_code", "zip", "address", "street"}
+)
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// matches reports whether r is the recorded request of other. The headers
+// change between runs, like the user agent, and aren't compared.
+func (r Request) matches(other Request) bool {
+	return r.Method == other.Method && r.URL == other.URL && r.Body == other.Body
+}
+
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Load reads the cassette at path.
+func Load(path string) (*Cassette, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(raw, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cassette %s: %w", path, err)
+	}
+
+	return &cassette, nil
+}
+
+// Save writes the cassette to path, replacing it atomically.
+func (c *Cassette) Save(path string) error {
+	raw, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(raw, '\n'), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// Transport is an http.RoundTripper that records the traffic into a
+// cassette or replays it from one.
+type Transport struct {
+	mode string
+	path string
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// New returns a Transport in the given mode. Recording sends the requests
+// through next and saves the cassette after every response; replaying reads
+// path and never touches the network.
+func New(mode, path string, next http.RoundTripper) (*Transport, error) {
+	t := &Transport{mode: mode, path: path, next: next}
+	switch mode {
+	case ModeRecord:
+		t.cassette = &Cassette{}
+		if next == nil {
+			t.next = http.DefaultTransport
+		}
+	case ModeReplay:
+		cassette, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		t.cassette = cassette
+		t.used = make([]bool, len(cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("unsupported mode %q, use %s or %s", mode, ModeRecord, ModeReplay)
+	}
+
+	return t, nil
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := Request{Method: req.Method, URL: req.URL.String(), Header: sanitizeHeader(req.Header), Body: sanitizeBody(body)}
+
+	if t.mode == ModeReplay {
+		return t.replay(req, recorded)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	header := sanitizeHeader(resp.Header)
+	// The length changes when the body is sanitized.
+	header.Del("Content-Length")
+	header.Del("Date")
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cassette.Interactions = append(t.cassette.Interactions, Interaction{
+		Request:  recorded,
+		Response: Response{Status: resp.StatusCode, Header: header, Body: sanitizeBody(respBody)},
+	})
+	if err := t.cassette.Save(t.path); err != nil {
+		return nil, fmt.Errorf("failed to save cassette: %w", err)
+	}
+
+	return resp, nil
+}
+
+// replay answers with the first unused interaction matching the request.
+// Once all of them were used the last match is repeated, the same request
+// can be sent any number of times.
+func (t *Transport) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	match := -1
+	for i, interaction := range t.cassette.Interactions {
+		if !interaction.Request.matches(recorded) {
+			continue
+		}
+		match = i
+		if !t.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("vcr: no recorded response for %s %s in %s", recorded.Method, recorded.URL, t.path)
+	}
+	t.used[match] = true
+
+	response := t.cassette.Interactions[match].Response
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.Status, http.StatusText(response.Status)),
+		StatusCode:    response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        response.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(response.Body)),
+		ContentLength: int64(len(response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// sanitizeHeader copies header replacing the values of sensitiveHeaders.
+func sanitizeHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	sanitized := header.Clone()
+	for _, name := range sensitiveHeaders {
+		if sanitized.Get(name) != "" {
+			sanitized.Set(name, Redacted)
+		}
+	}
+
+	return sanitized
+}
+
+// sanitizeBody replaces the sensitive fields of a JSON body. Bodies that are
+// not JSON are kept as they are.
+func sanitizeBody(body []byte) string {
+	var value any
+	if len(bytes.TrimSpace(body)) == 0 || json.Unmarshal(body, &value) != nil {
+		return string(body)
+	}
+
+	raw, err := json.Marshal(redact(value))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(raw)
+}
+
+func redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		address := false
+		for key := range v {
+			if matchesField(addressMarkers, key) {
+				address = true
+				break
+			}
+		}
+		for key, field := range v {
+			if isSensitive(key) || address && matchesField(addressFields, key) {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redact(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redact(item)
+		}
+	}
+
+	return value
+}
+
+func isSensitive(key string) bool {
+	return matchesField(sensitiveFields, key)
+}
+
+func matchesField(fields []string, key string) bool {
+	key = normalizeField(key)
+	for _, field := range fields {
+		if key == normalizeField(field) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeField(key string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+}
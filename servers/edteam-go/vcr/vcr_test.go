@@ -0,0 +1,147 @@
+package vcr_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"edteam-mcp/pkg/edteam"
+	"edteam-mcp/testsupport"
+	"edteam-mcp/vcr"
+)
+
+// TestReplayCassette replays testdata/cassettes/account.json, recorded from
+// the fake EDteam with the client, and checks what survived the redaction.
+func TestReplayCassette(t *testing.T) {
+	client, err := edteam.New(edteam.WithHTTPClient(&http.Client{Transport: testsupport.Replay(t, "account")}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	token, err := client.Login(ctx, "someone@example.com", "another password")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if token != vcr.Redacted {
+		t.Errorf("Login() = %q, want %q", token, vcr.Redacted)
+	}
+
+	subscriptions, err := client.Subscriptions.List(ctx, token)
+	if err != nil {
+		t.Fatalf("Subscriptions.List() error = %v", err)
+	}
+	if len(subscriptions.Data) != 2 {
+		t.Fatalf("Subscriptions.List() returned %d subscriptions, want 2", len(subscriptions.Data))
+	}
+	if got := subscriptions.Data[1]; got.State != "active" || got.Buyer != vcr.Redacted {
+		t.Errorf("subscription = state %q buyer %q, want the state kept and the buyer redacted", got.State, got.Buyer)
+	}
+
+	address, err := client.Billing.Address(ctx, token)
+	if err != nil {
+		t.Fatalf("Billing.Address() error = %v", err)
+	}
+	want := edteam.BillingAddress{
+		Name:       vcr.Redacted,
+		TaxID:      vcr.Redacted,
+		Address:    vcr.Redacted,
+		City:       vcr.Redacted,
+		State:      vcr.Redacted,
+		PostalCode: vcr.Redacted,
+		Country:    "PE",
+	}
+	if address.Data != want {
+		t.Errorf("Billing.Address() = %+v, want %+v", address.Data, want)
+	}
+
+	methods, err := client.Billing.PaymentMethods(ctx, token)
+	if err != nil {
+		t.Fatalf("Billing.PaymentMethods() error = %v", err)
+	}
+	if got := methods.Data[0]; got.Brand != "visa" || got.Last4 != vcr.Redacted || got.Expires != vcr.Redacted {
+		t.Errorf("payment method = %+v, want the brand kept and the card redacted", got)
+	}
+
+	// The last match is repeated once every interaction was used.
+	if _, err := client.Subscriptions.List(ctx, token); err != nil {
+		t.Errorf("Subscriptions.List() again error = %v", err)
+	}
+
+	_, err = client.Billing.Gift(ctx, token, 101, "friend@example.com")
+	if err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Errorf("Billing.Gift() error = %v, want no recorded response", err)
+	}
+}
+
+func TestRecordRedactsSecrets(t *testing.T) {
+	secrets := []string{"s3cret", "tok-123", "session=abc", "Robles", "20123456789", "Jr. Puno 456", "4242"}
+	edteamAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.Write([]byte(`{"data":{"token":"tok-123","profile":{"firstname":"Ana","lastname":"Robles"},` +
+			`"billing":{"name":"Ana Robles","tax_id":"20123456789","address":"Jr. Puno 456","country":"PE"},` +
+			`"cards":[{"brand":"visa","last4":"4242"}],"course":{"name":"Go Avanzado"},"state":"active"}}`))
+	}))
+	defer edteamAPI.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	transport, err := vcr.New(vcr.ModeRecord, path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, edteamAPI.URL+"/api/v1/login", strings.NewReader(`{"email":"ana@example.com","password":"s3cret"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer tok-123")
+	req.Header.Set("Cookie", "session=abc")
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range secrets {
+		if strings.Contains(string(raw), secret) {
+			t.Errorf("the cassette contains %q:\n%s", secret, raw)
+		}
+	}
+
+	cassette, err := vcr.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	interaction := cassette.Interactions[0]
+	for _, header := range []http.Header{interaction.Request.Header, interaction.Response.Header} {
+		for _, name := range []string{"Authorization", "Cookie", "Set-Cookie"} {
+			if value := header.Get(name); value != "" && value != vcr.Redacted {
+				t.Errorf("header %s = %q, want %q", name, value, vcr.Redacted)
+			}
+		}
+	}
+	for _, kept := range []string{"Go Avanzado", "active", "visa", "PE"} {
+		if !strings.Contains(interaction.Response.Body, kept) {
+			t.Errorf("the response lost %q: %s", kept, interaction.Response.Body)
+		}
+	}
+}
+
+func TestNewUnknownMode(t *testing.T) {
+	_, err := vcr.New("rewind", "cassette.json", nil)
+	if err == nil {
+		t.Fatal("New() error = nil, want an error for an unknown mode")
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		t.Errorf("New() error = %v, want the mode reported before reading the cassette", err)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// version is set at build time with -ldflags "-X main.version=v1.2.3". The
+// module version from the build info is used when it is not set.
+var version = ""
+
+type BuildInfo struct {
+	Version    string `json:"version"`
+	Commit     string `json:"commit,omitempty"`
+	CommitTime string `json:"commit_time,omitempty"`
+	Modified   bool   `json:"modified,omitempty"`
+	Go         string `json:"go"`
+	MCPGo      string `json:"mcp_go"`
+}
+
+// ConfigSummary is the configuration reported by the Version tool, without
+// credentials or keys.
+type ConfigSummary struct {
+	Transport          string   `json:"transport"`
+	Locale             Locale   `json:"locale"`
+	ReadOnly           bool     `json:"read_only"`
+	MultiTenant        bool     `json:"multi_tenant"`
+	Daemon             bool     `json:"daemon"`
+	APIKey             bool     `json:"api_key"`
+	EnabledTools       []string `json:"enabled_tools,omitempty"`
+	DisabledTools      []string `json:"disabled_tools,omitempty"`
+	PageSize           int      `json:"page_size"`
+	MaxPageSize        int      `json:"max_page_size"`
+	CatalogTTL         string   `json:"catalog_ttl"`
+	RetryAttempts      int      `json:"retry_max_attempts"`
+	MaxResponseBytes   int      `json:"max_response_bytes"`
+	SyncInterval       string   `json:"sync_interval"`
+	SyncSchedule       string   `json:"sync_schedule,omitempty"`
+	DigestSchedule     string   `json:"digest_schedule,omitempty"`
+	CurrencyRates      bool     `json:"currency_rates"`
+	EDteamBaseURL      bool     `json:"edteam_base_url"`
+	DriftWarnings      bool     `json:"drift_warnings"`
+	ConfirmSideEffects bool     `json:"confirm_side_effects"`
+	SpendingCap        string   `json:"spending_cap,omitempty"`
+	Telemetry          bool     `json:"telemetry"`
+	AllowedOrigins     []string `json:"allowed_origins,omitempty"`
+	DataDir            string   `json:"data_dir"`
+}
+
+type VersionInfo struct {
+	BuildInfo
+	Config ConfigSummary `json:"config"`
+}
+
+func buildInfo() BuildInfo {
+	info := BuildInfo{Version: version, Go: runtime.Version()}
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		if info.Version == "" {
+			info.Version = "devel"
+		}
+		return info
+	}
+
+	if info.Version == "" {
+		info.Version = build.Main.Version
+	}
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Commit = setting.Value
+		case "vcs.time":
+			info.CommitTime = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+	for _, dep := range build.Deps {
+		if dep.Path == "github.com/mark3labs/mcp-go" {
+			info.MCPGo = dep.Version
+		}
+	}
+
+	return info
+}
+
+func configSummary(cfg Config) ConfigSummary {
+	summary := ConfigSummary{
+		Transport:          cfg.Transport,
+		Locale:             cfg.Locale,
+		ReadOnly:           cfg.ReadOnly,
+		MultiTenant:        cfg.MultiTenant,
+		Daemon:             cfg.Daemon,
+		APIKey:             cfg.APIKey != "",
+		EnabledTools:       cfg.EnabledTools,
+		DisabledTools:      cfg.DisabledTools,
+		PageSize:           cfg.DefaultPageSize,
+		MaxPageSize:        cfg.MaxPageSize,
+		CatalogTTL:         cfg.CatalogTTL.String(),
+		RetryAttempts:      cfg.Retry.MaxAttempts,
+		MaxResponseBytes:   cfg.MaxResponseBytes,
+		SyncInterval:       cfg.SyncInterval.String(),
+		CurrencyRates:      cfg.CurrencyRates != "",
+		EDteamBaseURL:      cfg.EDteamBaseURL != "",
+		DriftWarnings:      cfg.DriftWarnings,
+		ConfirmSideEffects: cfg.ConfirmSideEffects,
+		SpendingCap:        cfg.SpendingCap.String(),
+		Telemetry:          cfg.Telemetry,
+		AllowedOrigins:     cfg.AllowedOrigins,
+		DataDir:            cfg.DataDir,
+	}
+	if cfg.SyncSchedule != nil {
+		summary.SyncSchedule = cfg.SyncSchedule.String()
+	}
+	if cfg.DigestSchedule != nil {
+		summary.DigestSchedule = cfg.DigestSchedule.String()
+	}
+
+	return summary
+}
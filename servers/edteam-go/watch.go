@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	WatchAdd    = "watch"
+	WatchRemove = "unwatch"
+	WatchList   = "list"
+)
+
+var watchActions = []string{WatchAdd, WatchRemove, WatchList}
+
+// Watch is a course the user wants to hear about when it goes on sale.
+type Watch struct {
+	CourseID int       `json:"course_id"`
+	Name     string    `json:"name"`
+	AddedAt  time.Time `json:"added_at"`
+	// OnSale is true while the current sale was already notified, so each
+	// sale is notified once.
+	OnSale bool `json:"on_sale"`
+	// Pending is the sale not yet shown by Whats-New.
+	Pending *SaleAlert `json:"pending,omitempty"`
+}
+
+type SaleAlert struct {
+	CourseID  int       `json:"course_id"`
+	Name      string    `json:"name"`
+	Price     int       `json:"price"`
+	BasePrice int       `json:"base_price"`
+	Currency  string    `json:"currency"`
+	SeenAt    time.Time `json:"seen_at"`
+	URL       string    `json:"url"`
+}
+
+// Watchlist keeps the watched courses in a JSON file in the data directory.
+type Watchlist struct {
+	path string
+
+	mu sync.Mutex
+}
+
+func NewWatchlist(dataDir string) *Watchlist {
+	return &Watchlist{path: filepath.Join(dataDir, "watchlist.json")}
+}
+
+func (w *Watchlist) Add(courseID int, name string, now time.Time) ([]Watch, error) {
+	return w.update(func(watches map[int]Watch) {
+		if _, ok := watches[courseID]; !ok {
+			watches[courseID] = Watch{CourseID: courseID, Name: name, AddedAt: now}
+		}
+	})
+}
+
+func (w *Watchlist) Remove(courseID int) ([]Watch, error) {
+	return w.update(func(watches map[int]Watch) {
+		delete(watches, courseID)
+	})
+}
+
+func (w *Watchlist) List() ([]Watch, error) {
+	return w.update(func(map[int]Watch) {})
+}
+
+// CheckSales compares the watched courses with the catalog and returns the
+// ones that went on sale since the last check.
+func (w *Watchlist) CheckSales(courses CourseResponse, codes map[int]string, now time.Time) ([]SaleAlert, error) {
+	var alerts []SaleAlert
+	_, err := w.update(func(watches map[int]Watch) {
+		for i, item := range courses.Data {
+			watch, ok := watches[item.Course.ID]
+			if !ok {
+				continue
+			}
+			sale, onSale := bestSale(courses, i, codes)
+			if !onSale {
+				watch.OnSale = false
+				watches[watch.CourseID] = watch
+				continue
+			}
+			if watch.OnSale {
+				continue
+			}
+			sale.CourseID = item.Course.ID
+			sale.Name = item.Course.Name
+			sale.SeenAt = now
+			sale.URL = classURL(item.Course.Slug, "")
+			watch.OnSale = true
+			watch.Pending = &sale
+			watches[watch.CourseID] = watch
+			alerts = append(alerts, sale)
+		}
+	})
+
+	return alerts, err
+}
+
+// TakePending returns the sales not shown yet and marks them as shown.
+func (w *Watchlist) TakePending() ([]SaleAlert, error) {
+	alerts := []SaleAlert{}
+	_, err := w.update(func(watches map[int]Watch) {
+		for id, watch := range watches {
+			if watch.Pending == nil {
+				continue
+			}
+			alerts = append(alerts, *watch.Pending)
+			watch.Pending = nil
+			watches[id] = watch
+		}
+	})
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].CourseID < alerts[j].CourseID })
+
+	return alerts, err
+}
+
+// update loads the watchlist, applies fn and saves it back.
+func (w *Watchlist) update(fn func(map[int]Watch)) ([]Watch, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	watches := map[int]Watch{}
+	data, err := os.ReadFile(w.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		var list []Watch
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, fmt.Errorf("invalid watchlist %s: %w", w.path, err)
+		}
+		for _, watch := range list {
+			watches[watch.CourseID] = watch
+		}
+	}
+
+	fn(watches)
+
+	list := make([]Watch, 0, len(watches))
+	for _, watch := range watches {
+		list = append(list, watch)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CourseID < list[j].CourseID })
+
+	data, err = json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(w.path), 0o700); err != nil {
+		return nil, err
+	}
+	tmp := w.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return nil, err
+	}
+
+	return list, os.Rename(tmp, w.path)
+}
+
+// bestSale returns the price of the course at index i with the biggest
+// discount.
+func bestSale(courses CourseResponse, i int, codes map[int]string) (SaleAlert, bool) {
+	var sale SaleAlert
+	found := false
+	for _, price := range courses.Data[i].CoursePrices {
+		if price.Price >= price.BasePrice {
+			continue
+		}
+		if found && price.BasePrice-price.Price <= sale.BasePrice-sale.Price {
+			continue
+		}
+		sale = SaleAlert{Price: price.Price, BasePrice: price.BasePrice, Currency: codes[price.CurrencyId]}
+		found = true
+	}
+
+	return sale, found
+}
+
+// syncCatalog refreshes the catalog, which also records the prices, and
+// notifies the clients about the watched courses that went on sale, the new
+// courses of the followed topics and the subscription about to expire.
+// Clients that don't show notifications see the sales in Whats-New. The
+// watchlist is nil on a multi-tenant server, the new courses are public and
+// go to every client.
+func syncCatalog(ctx context.Context, notifier *Notifier, catalog *Catalog, watchlist *Watchlist, codes map[int]string) {
+	notifier.Subscription(ctx, time.Now())
+
+	courses, err := catalog.Courses(ctx)
+	if err != nil {
+		log.Printf("failed to sync the catalog: %v", err)
+		return
+	}
+	notifier.NewCourses(courses)
+	if watchlist == nil {
+		return
+	}
+	alerts, err := watchlist.CheckSales(courses, codes, time.Now())
+	if err != nil {
+		log.Printf("failed to check the watched courses: %v", err)
+		return
+	}
+	notifier.Sales(alerts)
+}
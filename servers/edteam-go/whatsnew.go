@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// whatsNewWindow is how far back Whats-New looks on the first call, when
+// there is no snapshot to compare with.
+const whatsNewWindow = 7 * 24 * time.Hour
+
+// Snapshot is the content seen by the last Whats-New call.
+type Snapshot struct {
+	TakenAt time.Time `json:"taken_at"`
+	Courses []int     `json:"courses"`
+	Posts   []int     `json:"posts"`
+}
+
+type WhatsNew struct {
+	Since   time.Time   `json:"since"`
+	Courses []NewCourse `json:"courses"`
+	Posts   []NewPost   `json:"posts"`
+	// Sales are the watched courses that went on sale since the last call.
+	Sales   []SaleAlert `json:"sales"`
+	Message string      `json:"message"`
+}
+
+type NewCourse struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Level       string    `json:"level"`
+	PublishedAt time.Time `json:"published_at"`
+	URL         string    `json:"url"`
+}
+
+type NewPost struct {
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`
+	Summary     string    `json:"summary"`
+	PublishedAt time.Time `json:"published_at"`
+	URL         string    `json:"url"`
+}
+
+func snapshotPath(dataDir string) string {
+	return filepath.Join(dataDir, "snapshot.json")
+}
+
+// loadSnapshot returns false when there is no snapshot yet.
+func loadSnapshot(path string) (Snapshot, bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Snapshot{}, false, nil
+	}
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return Snapshot{}, false, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+
+	return snapshot, true, nil
+}
+
+// saveSnapshot writes the snapshot to a temporary file first, so a crash
+// never leaves a truncated snapshot behind.
+func saveSnapshot(path string, snapshot Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+func takeSnapshot(courses CourseResponse, posts BlogPostsResponse, now time.Time) Snapshot {
+	snapshot := Snapshot{TakenAt: now, Courses: []int{}, Posts: []int{}}
+	for _, item := range courses.Data {
+		snapshot.Courses = append(snapshot.Courses, item.Course.ID)
+	}
+	for _, post := range posts.Data {
+		snapshot.Posts = append(snapshot.Posts, post.ID)
+	}
+
+	return snapshot
+}
+
+// whatsNew returns the content published after since. Without since, the
+// content missing from the previous snapshot is new, which also catches
+// courses published with an older date; without a snapshot either, it
+// looks back whatsNewWindow.
+func whatsNew(courses CourseResponse, posts BlogPostsResponse, since *time.Time, previous *Snapshot, now time.Time, locale Locale) WhatsNew {
+	isNew := func(id int, publishedAt time.Time, seen []int) bool {
+		if since == nil && previous != nil {
+			return !containsInt(seen, id)
+		}
+		return !publishedAt.Before(newSince(since, previous, now))
+	}
+
+	result := WhatsNew{
+		Since:   newSince(since, previous, now),
+		Courses: []NewCourse{},
+		Posts:   []NewPost{},
+		Sales:   []SaleAlert{},
+	}
+	var seenCourses, seenPosts []int
+	if previous != nil {
+		seenCourses, seenPosts = previous.Courses, previous.Posts
+	}
+	for _, item := range courses.Data {
+		course := item.Course
+		if !course.Visible || !isNew(course.ID, course.CreatedAt.Time, seenCourses) {
+			continue
+		}
+		result.Courses = append(result.Courses, NewCourse{
+			ID:          course.ID,
+			Name:        course.Name,
+			Level:       course.Level,
+			PublishedAt: course.CreatedAt.Time,
+			URL:         classURL(course.Slug, ""),
+		})
+	}
+	for _, post := range posts.Data {
+		if !isNew(post.ID, post.PublishedAt.Time, seenPosts) {
+			continue
+		}
+		result.Posts = append(result.Posts, NewPost{
+			ID:          post.ID,
+			Title:       post.Title,
+			Summary:     post.Summary,
+			PublishedAt: post.PublishedAt.Time,
+			URL:         fmt.Sprintf("https://ed.team/blog/%s", post.Slug),
+		})
+	}
+	sort.Slice(result.Courses, func(i, j int) bool { return result.Courses[i].PublishedAt.After(result.Courses[j].PublishedAt) })
+	sort.Slice(result.Posts, func(i, j int) bool { return result.Posts[i].PublishedAt.After(result.Posts[j].PublishedAt) })
+
+	when := relativeTime(now, result.Since, locale)
+	if len(result.Courses) == 0 && len(result.Posts) == 0 {
+		result.Message = locale.T("whats_new_empty", when)
+	} else {
+		result.Message = locale.T("whats_new", len(result.Courses), len(result.Posts), when)
+	}
+
+	return result
+}
+
+func newSince(since *time.Time, previous *Snapshot, now time.Time) time.Time {
+	switch {
+	case since != nil:
+		return *since
+	case previous != nil:
+		return previous.TakenAt
+	default:
+		return now.Add(-whatsNewWindow)
+	}
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	SubscriptionActive  = "active"
+	SubscriptionExpired = "expired"
+	SubscriptionNone    = "none"
+)
+
+// Whoami is who the tools act as and what the account can watch.
+type Whoami struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	// SubscriptionState is active, expired when every subscription ended or
+	// none when the account never had one.
+	SubscriptionState       string     `json:"subscription_state"`
+	SubscriptionEndsAt      *time.Time `json:"subscription_ends_at,omitempty"`
+	SubscriptionEndsAtHuman string     `json:"subscription_ends_at_human,omitempty"`
+	TokenExpiresAt          *time.Time `json:"token_expires_at,omitempty"`
+	TokenExpiresAtHuman     string     `json:"token_expires_at_human,omitempty"`
+}
+
+func whoami(profile Profile, subscriptions SubscriptionResponse, token string, now time.Time, locale Locale) Whoami {
+	result := Whoami{
+		Name:              strings.TrimSpace(profile.Firstname + " " + profile.Lastname),
+		Email:             maskEmail(profile.Email),
+		SubscriptionState: SubscriptionNone,
+	}
+	if len(subscriptions.Data) > 0 {
+		result.SubscriptionState = SubscriptionExpired
+	}
+	if subscription, ok := activeSubscription(subscriptions, now); ok {
+		result.SubscriptionState = SubscriptionActive
+		result.SubscriptionEndsAt = &subscription.EndsAt.Time
+		result.SubscriptionEndsAtHuman = relativeTime(now, subscription.EndsAt.Time, locale)
+	}
+	if claims, ok := parseTokenClaims(token); ok && claims.ExpiresAt != nil {
+		result.TokenExpiresAt = claims.ExpiresAt
+		result.TokenExpiresAtHuman = relativeTime(now, *claims.ExpiresAt, locale)
+	}
+
+	return result
+}
+
+// maskEmail keeps the first letter of the user and the domain, enough to
+// tell the account apart without showing the address in the conversation.
+func maskEmail(email string) string {
+	user, domain, ok := strings.Cut(email, "@")
+	if !ok || user == "" {
+		return ""
+	}
+
+	first, size := utf8.DecodeRuneInString(user)
+
+	return string(first) + strings.Repeat("*", max(utf8.RuneCountInString(user[size:]), 3)) + "@" + domain
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMaskEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  string
+	}{
+		{email: "alexys@ed.team", want: "a*****@ed.team"},
+		{email: "al@ed.team", want: "a***@ed.team"},
+		{email: "ñandu@ed.team", want: "ñ****@ed.team"},
+		{email: "éa@ed.team", want: "é***@ed.team"},
+		{email: "@ed.team", want: ""},
+		{email: "alexys", want: ""},
+	}
+	for _, tt := range tests {
+		got := maskEmail(tt.email)
+		if got != tt.want || !utf8.ValidString(got) {
+			t.Errorf("maskEmail(%q) = %q, want %q", tt.email, got, tt.want)
+		}
+	}
+}